@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/anthdm/hollywood/actor"
+	"github.com/troygilman/actormq/cluster"
 )
 
 type heartbeatTimeout struct{}
@@ -69,6 +70,13 @@ func (client *clientActor) Receive(act *actor.Context) {
 			PID: act.SpawnChild(NewProducer(msg.ProducerConfig, client.config.Nodes), "producer"),
 		})
 
+	case RedriveDeadLetters:
+		act.Engine().SendWithSender(client.config.Nodes[0], &cluster.Redrive{
+			Topic:       msg.Topic,
+			From:        msg.From,
+			MaxMessages: msg.MaxMessages,
+		}, act.Sender())
+
 	}
 }
 
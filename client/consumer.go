@@ -12,6 +12,24 @@ import (
 type ConsumerConfig struct {
 	Topic        string
 	Deserializer remote.Deserializer
+	// StartPosition is where delivery starts: the next message applied (the default),
+	// the earliest message left in the log, or Offset.
+	// Consumers joining a Group that already has members continue from the group's position,
+	// and a Group that has committed an offset resumes from it.
+	StartPosition cluster.StartPosition
+	Offset        uint64
+	// Group shares the topic with other consumers of the same group, each message going to one of them.
+	// A Group is served by the topic's leader, and its consumers register again when leadership moves.
+	// The topic commits the group's position as its messages are acknowledged.
+	Group string
+	// MaxInFlight is the number of messages the topic sends before waiting for them to be acknowledged,
+	// and AckTimeout is how long it waits before sending a message again. Zero uses the topic's defaults.
+	MaxInFlight uint64
+	AckTimeout  time.Duration
+	// DeadLetterTopic receives the messages that could not be delivered in MaxDeliveries attempts,
+	// or in the topic's default number of attempts if it is zero.
+	DeadLetterTopic string
+	MaxDeliveries   uint64
 }
 
 type consumerActor struct {
@@ -33,24 +51,73 @@ func NewConsumer(config ConsumerConfig, pods []*actor.PID) actor.Producer {
 func (consumer *consumerActor) Receive(act *actor.Context) {
 	switch msg := act.Message().(type) {
 	case actor.Started:
-		result, err := handleResponse[*cluster.RegisterConsumerResult](act.Request(consumer.leader, &cluster.RegisterConsumer{
-			Topic: consumer.config.Topic,
-			PID:   cluster.ActorPIDToPID(act.PID()),
-		}, 10*time.Second))
-		if err != nil {
-			panic(err)
-		}
-		if !result.Success {
-			panic(result.Error)
+		consumer.register(act)
+
+	case *actor.Ping:
+		act.Send(act.Sender(), &actor.Pong{})
+
+	case *cluster.ConsumerRemoved:
+		log.Println("consumer removed:", msg.Reason)
+		if msg.RedirectPID != nil {
+			consumer.leader = cluster.PIDToActorPID(msg.RedirectPID)
 		}
-		log.Println("registered consumer")
+		consumer.register(act)
+
+	case *cluster.MessagesCompacted:
+		log.Printf("messages %d to %d were compacted before they were delivered\n", msg.From, msg.Next-1)
 
 	case *cluster.ConsumerEnvelope:
 		message, err := consumer.config.Deserializer.Deserialize(msg.Message.Data, msg.Message.TypeName)
 		if err != nil {
-			panic(err)
+			act.Send(act.Sender(), &cluster.Nack{
+				Topic:  consumer.config.Topic,
+				Offset: msg.Offset,
+				Reason: err.Error(),
+			})
+			return
 		}
-		log.Printf("%T - %+v\n", message, message)
+		log.Printf("%d - %T - %+v\n", msg.Offset, message, message)
+		act.Send(act.Sender(), &cluster.Ack{
+			Topic:  consumer.config.Topic,
+			Offset: msg.Offset,
+		})
 
 	}
 }
+
+// register registers the consumer with the topic, following redirects to the leader's pod for a Group.
+// Registrations that fail while the topic has no leader are retried after a backoff.
+func (consumer *consumerActor) register(act *actor.Context) {
+	request := &cluster.RegisterConsumer{
+		Topic:           consumer.config.Topic,
+		PID:             cluster.ActorPIDToPID(act.PID()),
+		StartPosition:   consumer.config.StartPosition,
+		Offset:          consumer.config.Offset,
+		Group:           consumer.config.Group,
+		MaxInFlight:     consumer.config.MaxInFlight,
+		AckTimeout:      int64(consumer.config.AckTimeout),
+		DeadLetterTopic: consumer.config.DeadLetterTopic,
+		MaxDeliveries:   consumer.config.MaxDeliveries,
+	}
+	backoff := minRetryBackoff
+	for {
+		result, err := handleResponse[*cluster.RegisterConsumerResult](act.Request(consumer.leader, request, 10*time.Second))
+		if err != nil {
+			panic(err)
+		}
+		if result.Success {
+			log.Println("registered consumer")
+			return
+		}
+		if result.RedirectPID != nil {
+			consumer.leader = cluster.PIDToActorPID(result.RedirectPID)
+			continue
+		}
+		if result.Error != "not the leader" {
+			panic(result.Error)
+		}
+		log.Println("registering consumer:", result.Error)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
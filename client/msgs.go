@@ -21,3 +21,14 @@ type CreateProducerResult struct {
 type ProduceMessage struct {
 	Message any
 }
+
+// RedriveDeadLetters publishes the dead letters in a dead-letter topic back to their original topics,
+// starting at offset From and stopping after MaxMessages if it is not zero.
+// It is answered with a *cluster.RedriveResult.
+// Redriving the same offsets again does not publish their messages twice; the messages it skips,
+// and any before an offset already redriven, are reported in Duplicates.
+type RedriveDeadLetters struct {
+	Topic       string
+	From        uint64
+	MaxMessages uint64
+}
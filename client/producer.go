@@ -1,6 +1,9 @@
 package client
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"log"
 	"time"
 
@@ -9,15 +12,23 @@ import (
 	"github.com/troygilman/actormq/cluster"
 )
 
+const (
+	minRetryBackoff = 50 * time.Millisecond
+	maxRetryBackoff = 2 * time.Second
+)
+
 type ProducerConfig struct {
 	Topic      string
 	Serializer remote.Serializer
 }
 
 type producerActor struct {
-	config ProducerConfig
-	pods   []*actor.PID
-	leader *actor.PID
+	config     ProducerConfig
+	pods       []*actor.PID
+	pod        int
+	leader     *actor.PID
+	producerID string
+	sequence   uint64
 }
 
 func NewProducer(config ProducerConfig, pods []*actor.PID) actor.Producer {
@@ -32,32 +43,59 @@ func NewProducer(config ProducerConfig, pods []*actor.PID) actor.Producer {
 
 func (producer *producerActor) Receive(act *actor.Context) {
 	switch msg := act.Message().(type) {
+	case actor.Started:
+		// A random ID keeps a restarted producer from reusing the sequence numbers of its predecessor
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			panic(err)
+		}
+		producer.producerID = hex.EncodeToString(id)
+
 	case ProduceMessage:
 		data, err := producer.config.Serializer.Serialize(msg.Message)
 		if err != nil {
 			panic(err)
 		}
+		producer.sequence++
 		envelope := &cluster.Envelope{
 			Topic: producer.config.Topic,
 			Message: &cluster.Message{
 				TypeName: producer.config.Serializer.TypeName(msg.Message),
 				Data:     data,
 			},
+			ProducerID: producer.producerID,
+			Sequence:   producer.sequence,
 		}
 		log.Println(envelope)
-		for {
-			result, err := handleResponse[*cluster.EnvelopeResult](act.Request(producer.leader, envelope, 10*time.Second))
-			if err != nil {
-				panic(err)
-			}
-			if !result.Success {
-				if result.RedirectPID != nil {
-					producer.leader = cluster.PIDToActorPID(result.RedirectPID)
-					continue
-				}
-				panic(result.Error)
-			}
-			break
+		producer.produce(act, envelope)
+	}
+}
+
+// produce sends envelope to the leader until it is appended, following redirects.
+// Failed attempts are retried with the same sequence number after a backoff,
+// so an envelope that was appended before its result was lost is dropped as a duplicate.
+// A producer whose request fails tries the next pod, as the one it used may be down.
+func (producer *producerActor) produce(act *actor.Context, envelope *cluster.Envelope) {
+	backoff := minRetryBackoff
+	for {
+		result, err := handleResponse[*cluster.EnvelopeResult](act.Request(producer.leader, envelope, 10*time.Second))
+		if err == nil && result.Success {
+			return
+		}
+		if err == nil && result.RedirectPID != nil {
+			producer.leader = cluster.PIDToActorPID(result.RedirectPID)
+			continue
+		}
+		if err != nil {
+			producer.pod = (producer.pod + 1) % len(producer.pods)
+			producer.leader = producer.pods[producer.pod]
+		} else if result.Error != "" {
+			err = errors.New(result.Error)
+		} else {
+			err = errors.New("topic has no leader")
 		}
+		log.Println("producing message:", err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxRetryBackoff)
 	}
 }
@@ -0,0 +1,280 @@
+package cluster
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// NodeObserver is called by a node after it has handled each message.
+// A node calls it from its own goroutine, so Observe must be safe for concurrent use.
+type NodeObserver interface {
+	Observe(node *RaftNode)
+}
+
+// defaultObserver is used by NewNodeConfig and is set by debug builds.
+var defaultObserver NodeObserver
+
+// InvariantViolation describes a broken Raft safety property along with the log of every observed node.
+type InvariantViolation struct {
+	Invariant string
+	Detail    string
+	Logs      string
+}
+
+func (violation *InvariantViolation) Error() string {
+	return fmt.Sprintf("raft invariant %q violated: %s\n%s", violation.Invariant, violation.Detail, violation.Logs)
+}
+
+// InvariantChecker observes every node of a group and checks the safety properties of Raft:
+//   - Election safety: at most one leader is elected in a term.
+//   - Log matching: entries with the same index and term are identical, and so are the logs before them.
+//   - Leader completeness: a leader's log holds every entry committed before it was elected.
+//   - State machine safety: no two nodes commit, and therefore apply, different entries at the same index.
+//
+// Entries are compared by checksum, and compacted entries are assumed to be committed.
+// Nodes are grouped by topic, so one checker can observe every Raft group in a process.
+type InvariantChecker struct {
+	mu          sync.Mutex
+	onViolation func(violation *InvariantViolation)
+	violation   *InvariantViolation
+	groups      map[string]*checkedGroup
+}
+
+// checkedGroup is what the checker knows about the Raft group of a single topic.
+type checkedGroup struct {
+	leaders   map[uint64]string
+	entries   map[logPosition]checkedEntry
+	committed map[uint64]checkedEntry
+	nodes     map[string]*checkedNode
+}
+
+type logPosition struct {
+	index uint64
+	term  uint64
+}
+
+type checkedEntry struct {
+	term     uint64
+	prevTerm uint64
+	checksum uint32
+}
+
+// checkedNode is the checker's copy of a node's log, so it can be dumped without touching other nodes.
+type checkedNode struct {
+	firstIndex  uint64
+	entries     []checkedEntry
+	commitIndex uint64
+	leaderTerm  uint64
+	// raft and lastCommitIndex are the node last observed under this PID and its commit index,
+	// which only a restart may lower
+	raft            *RaftNode
+	lastCommitIndex uint64
+}
+
+// NewInvariantChecker returns an InvariantChecker that panics on the first violation.
+func NewInvariantChecker() *InvariantChecker {
+	return NewInvariantCheckerWithHandler(func(violation *InvariantViolation) {
+		panic(violation)
+	})
+}
+
+// NewInvariantCheckerWithHandler returns an InvariantChecker that calls onViolation for the first violation
+// and stops checking afterwards.
+func NewInvariantCheckerWithHandler(onViolation func(violation *InvariantViolation)) *InvariantChecker {
+	return &InvariantChecker{
+		onViolation: onViolation,
+		groups:      make(map[string]*checkedGroup),
+	}
+}
+
+// Err returns the first violation found, if any.
+func (checker *InvariantChecker) Err() error {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if checker.violation == nil {
+		return nil
+	}
+	return checker.violation
+}
+
+func (checker *InvariantChecker) Observe(node *RaftNode) {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if checker.violation != nil || node.pid == nil || node.store == nil {
+		return
+	}
+	group, ok := checker.groups[node.config.Topic]
+	if !ok {
+		group = &checkedGroup{
+			leaders:   make(map[uint64]string),
+			entries:   make(map[logPosition]checkedEntry),
+			committed: make(map[uint64]checkedEntry),
+			nodes:     make(map[string]*checkedNode),
+		}
+		checker.groups[node.config.Topic] = group
+	}
+	if err := group.observe(node); err != nil {
+		checker.violation = &InvariantViolation{
+			Invariant: err.invariant,
+			Detail:    err.detail,
+			Logs:      group.dumpLogs(),
+		}
+		checker.onViolation(checker.violation)
+	}
+}
+
+type checkError struct {
+	invariant string
+	detail    string
+}
+
+func (group *checkedGroup) observe(node *RaftNode) *checkError {
+	key := node.pid.String()
+	checked, ok := group.nodes[key]
+	if !ok {
+		checked = &checkedNode{}
+		group.nodes[key] = checked
+	}
+
+	// Commit index monotonicity
+	if checked.raft == node && node.commitIndex < checked.lastCommitIndex {
+		return &checkError{
+			invariant: "commit index monotonicity",
+			detail:    fmt.Sprintf("%s lowered its commit index from %d to %d", key, checked.lastCommitIndex, node.commitIndex),
+		}
+	}
+	checked.raft = node
+	checked.lastCommitIndex = node.commitIndex
+
+	from, err := copyLog(node, checked)
+	if err != nil {
+		return err
+	}
+	lastIndex := checked.firstIndex + uint64(len(checked.entries))
+
+	// Log matching
+	for index := from; index < lastIndex; index++ {
+		entry := checked.entries[index-checked.firstIndex]
+		position := logPosition{
+			index: index,
+			term:  entry.term,
+		}
+		if other, ok := group.entries[position]; ok && other != entry {
+			return &checkError{
+				invariant: "log matching",
+				detail:    fmt.Sprintf("%s has a different entry or previous term at index %d term %d", key, position.index, position.term),
+			}
+		}
+		group.entries[position] = entry
+	}
+
+	// State machine safety
+	for index := max(checked.firstIndex, checked.commitIndex+1); index <= node.commitIndex && index < lastIndex; index++ {
+		entry := checked.entries[index-checked.firstIndex]
+		if other, ok := group.committed[index]; ok && other != entry {
+			return &checkError{
+				invariant: "state machine safety",
+				detail:    fmt.Sprintf("%s committed a different entry at index %d", key, index),
+			}
+		}
+		group.committed[index] = entry
+	}
+	checked.commitIndex = node.commitIndex
+
+	if !pidEquals(node.leader, node.pid) {
+		return nil
+	}
+
+	// Election safety
+	if leader, ok := group.leaders[node.currentTerm]; ok && leader != key {
+		return &checkError{
+			invariant: "election safety",
+			detail:    fmt.Sprintf("%s and %s are both leaders of term %d", leader, key, node.currentTerm),
+		}
+	}
+	group.leaders[node.currentTerm] = key
+
+	// Leader completeness is checked once, when the leader is first observed
+	if checked.leaderTerm == node.currentTerm {
+		return nil
+	}
+	checked.leaderTerm = node.currentTerm
+	for index, entry := range group.committed {
+		if index < checked.firstIndex {
+			continue
+		}
+		if index >= lastIndex || checked.entries[index-checked.firstIndex] != entry {
+			return &checkError{
+				invariant: "leader completeness",
+				detail:    fmt.Sprintf("%s became leader of term %d without the entry committed at index %d", key, node.currentTerm, index),
+			}
+		}
+	}
+	return nil
+}
+
+// copyLog updates the checker's copy of the node's log and returns the first index it copied.
+// Only the uncommitted suffix can change, so everything after the commit index observed last time is copied again.
+// The node's current commit index is no guide, as the step that advanced it may also have overwritten entries below it.
+func copyLog(node *RaftNode, checked *checkedNode) (uint64, *checkError) {
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		return 0, &checkError{invariant: "readable log", detail: err.Error()}
+	}
+	lastLogIndex, _ := node.store.LastIndexAndTerm()
+	firstIndex := snapshot.LastIncludedIndex + 1
+
+	from := firstIndex
+	if checked.firstIndex == firstIndex {
+		from = max(firstIndex, min(firstIndex+uint64(len(checked.entries)), checked.commitIndex+1, node.commitIndex+1, lastLogIndex+1))
+	} else {
+		checked.firstIndex = firstIndex
+		checked.entries = nil
+		checked.commitIndex = 0
+	}
+	checked.entries = checked.entries[:from-firstIndex]
+
+	prevTerm := snapshot.LastIncludedTerm
+	if from > firstIndex {
+		prevTerm = checked.entries[from-firstIndex-1].term
+	}
+	entries, err := node.store.Entries(from, lastLogIndex+1)
+	if err != nil {
+		return 0, &checkError{invariant: "readable log", detail: err.Error()}
+	}
+	for _, entry := range entries {
+		checksum, err := entryChecksum(entry)
+		if err != nil {
+			return 0, &checkError{invariant: "readable log", detail: err.Error()}
+		}
+		checked.entries = append(checked.entries, checkedEntry{
+			term:     entry.Term,
+			prevTerm: prevTerm,
+			checksum: checksum,
+		})
+		prevTerm = entry.Term
+	}
+	return from, nil
+}
+
+// dumpLogs describes the last observed log of every node in the group as index:term/checksum.
+func (group *checkedGroup) dumpLogs() string {
+	keys := make([]string, 0, len(group.nodes))
+	for key := range group.nodes {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		checked := group.nodes[key]
+		fmt.Fprintf(&builder, "%s (compacted up to %d):", key, checked.firstIndex-1)
+		for i, entry := range checked.entries {
+			fmt.Fprintf(&builder, " %d:%d/%08x", checked.firstIndex+uint64(i), entry.term, entry.checksum)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
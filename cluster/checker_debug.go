@@ -0,0 +1,9 @@
+//go:build raftcheck
+
+package cluster
+
+// Building with the raftcheck tag checks every node in the process against the Raft safety properties
+// and panics with a dump of every log on the first violation.
+func init() {
+	defaultObserver = NewInvariantChecker()
+}
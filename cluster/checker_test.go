@@ -0,0 +1,133 @@
+package cluster
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anthdm/hollywood/actor"
+)
+
+// checkedTestNode returns a node of the topic "test" with the given entries in its log.
+func checkedTestNode(t *testing.T, id string, entries ...*LogEntry) *RaftNode {
+	t.Helper()
+	store, err := NewMemoryLogStore()()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(entries...); err != nil {
+		t.Fatal(err)
+	}
+	return &RaftNode{
+		config: NodeConfig{
+			Topic: "test",
+		},
+		pid:   actor.NewPID("local", "node/"+id),
+		store: store,
+	}
+}
+
+// checkViolation observes every node in turn and checks that the checker reports a single violation of invariant
+// with every node's log.
+func checkViolation(t *testing.T, invariant string, nodes ...*RaftNode) {
+	t.Helper()
+	violations := 0
+	checker := NewInvariantCheckerWithHandler(func(*InvariantViolation) {
+		violations++
+	})
+	for _, node := range nodes {
+		checker.Observe(node)
+	}
+	// Nothing is checked once a violation has been found
+	checker.Observe(nodes[0])
+
+	var violation *InvariantViolation
+	if err := checker.Err(); !errors.As(err, &violation) {
+		t.Fatalf("checker returned %v", err)
+	}
+	if violations != 1 {
+		t.Fatalf("checker reported %d violations", violations)
+	}
+	if violation.Invariant != invariant {
+		t.Fatalf("checker found a violation of %q instead of %q: %s", violation.Invariant, invariant, violation.Detail)
+	}
+	for _, node := range nodes {
+		if !strings.Contains(violation.Logs, node.pid.String()+" (compacted up to 0): 1:") {
+			t.Fatalf("log dump is missing %s:\n%s", node.pid, violation.Logs)
+		}
+	}
+}
+
+func TestCheckerAgreement(t *testing.T) {
+	entries := testEntries(t, 1, 3)
+	leader := checkedTestNode(t, "1", entries...)
+	leader.currentTerm = 1
+	leader.leader = leader.pid
+	leader.commitIndex = 3
+	follower := checkedTestNode(t, "2", entries[:2]...)
+	follower.currentTerm = 1
+	follower.leader = leader.pid
+	follower.commitIndex = 2
+
+	checker := NewInvariantCheckerWithHandler(func(violation *InvariantViolation) {
+		t.Fatal(violation)
+	})
+	checker.Observe(leader)
+	checker.Observe(follower)
+	if err := checker.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckerElectionSafety(t *testing.T) {
+	entries := testEntries(t, 2, 1)
+	first := checkedTestNode(t, "1", entries...)
+	second := checkedTestNode(t, "2", entries...)
+	for _, node := range []*RaftNode{first, second} {
+		node.currentTerm = 2
+		node.leader = node.pid
+	}
+	checkViolation(t, "election safety", first, second)
+}
+
+func TestCheckerLogMatching(t *testing.T) {
+	entries := testEntries(t, 1, 2)
+	divergent := testEntries(t, 1, 2)
+	divergent[1].Message.Data = []byte("divergent")
+	if err := setChecksums(divergent...); err != nil {
+		t.Fatal(err)
+	}
+	checkViolation(t, "log matching", checkedTestNode(t, "1", entries...), checkedTestNode(t, "2", divergent...))
+}
+
+func TestCheckerStateMachineSafety(t *testing.T) {
+	first := checkedTestNode(t, "1", testEntries(t, 1, 2)...)
+	first.commitIndex = 2
+	second := checkedTestNode(t, "2", append(testEntries(t, 1, 1), testEntries(t, 2, 1)...)...)
+	second.commitIndex = 2
+	checkViolation(t, "state machine safety", first, second)
+}
+
+func TestCheckerCommitIndexMonotonicity(t *testing.T) {
+	node := checkedTestNode(t, "1", testEntries(t, 1, 3)...)
+	node.commitIndex = 3
+	checker := NewInvariantCheckerWithHandler(func(*InvariantViolation) {})
+	checker.Observe(node)
+
+	// A restarted node starts again from its snapshot
+	restarted := *node
+	restarted.commitIndex = 0
+	checker.Observe(&restarted)
+	if err := checker.Err(); err != nil {
+		t.Fatalf("restart reported %v", err)
+	}
+
+	restarted.commitIndex = 2
+	checker.Observe(&restarted)
+	restarted.commitIndex = 1
+	checker.Observe(&restarted)
+	var violation *InvariantViolation
+	if err := checker.Err(); !errors.As(err, &violation) || violation.Invariant != "commit index monotonicity" {
+		t.Fatalf("checker returned %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrChecksumMismatch is returned when a log entry does not match the checksum it was written with.
+var ErrChecksumMismatch = errors.New("log entry checksum mismatch")
+
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// entryChecksum returns the CRC-32C of entry with its checksum field cleared.
+func entryChecksum(entry *LogEntry) (uint32, error) {
+	entry = proto.Clone(entry).(*LogEntry)
+	entry.Checksum = nil
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+	return crc32.Checksum(data, checksumTable), nil
+}
+
+// setChecksums stamps every entry with its checksum before it is first appended to the log.
+func setChecksums(entries ...*LogEntry) error {
+	for _, entry := range entries {
+		checksum, err := entryChecksum(entry)
+		if err != nil {
+			return err
+		}
+		entry.Checksum = proto.Uint32(checksum)
+	}
+	return nil
+}
+
+// verifyChecksum checks the entry at index against its checksum.
+// Entries without a checksum were written before checksums were introduced and are accepted.
+func verifyChecksum(index uint64, entry *LogEntry) error {
+	if entry.Checksum == nil {
+		return nil
+	}
+	checksum, err := entryChecksum(entry)
+	if err != nil {
+		return err
+	}
+	if checksum != *entry.Checksum {
+		return fmt.Errorf("entry %d has checksum %08x but was written with %08x: %w", index, checksum, *entry.Checksum, ErrChecksumMismatch)
+	}
+	return nil
+}
@@ -0,0 +1,565 @@
+package cluster
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/anthdm/hollywood/actor"
+)
+
+const (
+	// replayBatchSize is the number of log entries read at a time for a subscription that is replaying the log.
+	replayBatchSize      = 256
+	consumerPingInterval = time.Second
+	consumerTimeout      = 5 * time.Second
+	defaultMaxInFlight   = 64
+	defaultAckTimeout    = 30 * time.Second
+	defaultMaxDeliveries = 5
+)
+
+type checkConsumers struct{}
+
+// handleRegisterConsumer confirms a group registration with a ReadIndex before registering it.
+// Groups are only served by the topic on the leader's pod, so the read redirects the registration there,
+// and brings the committed offset the group resumes from up to date.
+func (topic *topicActor) handleRegisterConsumer(act *actor.Context, msg *RegisterConsumer) {
+	if msg.Group != "" {
+		topic.nextReadID++
+		topic.pendingRegistrations[topic.nextReadID] = &registrationMetadata{
+			sender:  act.Sender(),
+			request: msg,
+		}
+		act.Send(topic.messagesPID, &ReadIndex{
+			Topic: topic.config.Topic,
+			Id:    topic.nextReadID,
+		})
+		return
+	}
+	topic.registerConsumer(act, act.Sender(), msg)
+}
+
+// registerConsumer adds the consumer to its subscription and answers sender.
+func (topic *topicActor) registerConsumer(act *actor.Context, sender *actor.PID, msg *RegisterConsumer) {
+	pid := PIDToActorPID(msg.PID)
+	key := pid.LookupKey()
+	maxInFlight := int(msg.MaxInFlight)
+	if maxInFlight == 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+	ackTimeout := time.Duration(msg.AckTimeout)
+	if ackTimeout == 0 {
+		ackTimeout = defaultAckTimeout
+	}
+
+	// A consumer that restarted registers again with the same PID and keeps its place,
+	// but the messages it had not acknowledged are sent again
+	if consumer, ok := topic.consumers[key]; ok {
+		consumer.lastPong = time.Now()
+		consumer.maxInFlight = maxInFlight
+		consumer.ackTimeout = ackTimeout
+		act.Send(sender, &RegisterConsumerResult{
+			Success: true,
+		})
+		topic.config.Logger.Info("Registered consumer again", "topic", topic.config.Topic, "consumer", pid, "group", msg.Group)
+		topic.redeliver(act, consumer.subscription, "consumer restarted", func(message *inflightMessage) bool {
+			return message.consumer == consumer
+		})
+		return
+	}
+
+	sub, joined := topic.groups[msg.Group]
+	if msg.Group == "" || !joined {
+		topic.nextSubscriptionID++
+		sub = &subscription{
+			id:              topic.nextSubscriptionID,
+			group:           msg.Group,
+			live:            msg.StartPosition == StartPosition_LATEST,
+			inflight:        make(map[uint64]*inflightMessage),
+			deadLetterTopic: msg.DeadLetterTopic,
+			maxDeliveries:   msg.MaxDeliveries,
+		}
+		if sub.maxDeliveries == 0 {
+			sub.maxDeliveries = defaultMaxDeliveries
+		}
+		if msg.StartPosition == StartPosition_OFFSET {
+			sub.next = msg.Offset
+		}
+		if offset, ok := topic.stateMachine.Offset(msg.Group); ok && msg.Group != "" {
+			sub.next = offset
+			sub.live = false
+		}
+		topic.subscriptions[sub.id] = sub
+		if msg.Group != "" {
+			topic.groups[msg.Group] = sub
+		}
+	}
+	consumer := &consumerMetadata{
+		pid:          pid,
+		lastPong:     time.Now(),
+		subscription: sub,
+		maxInFlight:  maxInFlight,
+		ackTimeout:   ackTimeout,
+	}
+	sub.members = append(sub.members, consumer)
+	topic.consumers[key] = consumer
+	act.Send(sender, &RegisterConsumerResult{
+		Success: true,
+	})
+	topic.config.Logger.Info("Registered consumer", "topic", topic.config.Topic, "consumer", pid, "group", msg.Group, "members", len(sub.members))
+
+	// A new member may have room for messages its group could not deliver
+	topic.redeliver(act, sub, "", func(message *inflightMessage) bool {
+		return message.consumer == nil
+	})
+	topic.resume(act, sub)
+	topic.updateRetention()
+}
+
+// checkConsumers removes the consumers that have not answered within consumerTimeout, pings the rest,
+// sends the messages whose ack deadline has passed again, and retries failed reads of the log.
+// It also asks the node who leads the topic, so groups are dropped once this pod no longer serves them.
+func (topic *topicActor) checkConsumers(act *actor.Context) {
+	if len(topic.groups) > 0 {
+		act.Send(topic.messagesPID, &NodeStatus{
+			Topic: topic.config.Topic,
+		})
+	}
+	for key, consumer := range topic.consumers {
+		if time.Since(consumer.lastPong) > consumerTimeout {
+			topic.removeConsumer(act, key)
+		} else {
+			act.Send(consumer.pid, &actor.Ping{})
+		}
+	}
+	now := time.Now()
+	for _, sub := range topic.subscriptions {
+		topic.redeliver(act, sub, "", func(message *inflightMessage) bool {
+			return message.consumer == nil
+		})
+		topic.redeliver(act, sub, "ack timeout", func(message *inflightMessage) bool {
+			return !now.Before(message.deadline)
+		})
+		if sub.readFailed {
+			topic.resume(act, sub)
+		}
+	}
+	topic.commitOffsets(act)
+	topic.updateRetention()
+}
+
+// commitOffsets commits the lowest offset each group still needs whenever it has moved since the last commit.
+// Offsets are committed through the log, so a group that registers again with any node loses no message.
+func (topic *topicActor) commitOffsets(act *actor.Context) {
+	for _, sub := range topic.groups {
+		offset := lowestOffset(sub)
+		if offset == 0 || offset == sub.committed {
+			continue
+		}
+		act.Send(topic.messagesPID, &CommitOffset{
+			Topic:  topic.config.Topic,
+			Group:  sub.group,
+			Offset: offset,
+		})
+		sub.committed = offset
+	}
+}
+
+// handleCommitOffsetResult logs a failed commit and makes every group commit again on the next check,
+// as the result does not say which group it was for.
+func (topic *topicActor) handleCommitOffsetResult(act *actor.Context, msg *CommitOffsetResult) {
+	if msg.Success {
+		return
+	}
+	topic.config.Logger.Error("Committing offsets", "topic", topic.config.Topic, "error", msg.Error)
+	for _, sub := range topic.groups {
+		sub.committed = 0
+	}
+}
+
+// updateRetention keeps the log from being compacted past the lowest offset a subscription still needs.
+func (topic *topicActor) updateRetention() {
+	retain := uint64(math.MaxUint64)
+	for _, sub := range topic.subscriptions {
+		if sub.live && sub.next == 0 && len(sub.inflight) == 0 {
+			// A subscription starting at the latest message has not been sent anything yet and needs no old entries
+			continue
+		}
+		retain = min(retain, lowestOffset(sub))
+	}
+	topic.stateMachine.retainSubscriptions(retain)
+}
+
+// lowestOffset returns the offset of the oldest message sub has not had acknowledged,
+// or the next offset it will deliver if every message has been.
+func lowestOffset(sub *subscription) uint64 {
+	offset := sub.next
+	for inflight := range sub.inflight {
+		offset = min(offset, inflight)
+	}
+	return offset
+}
+
+// handleNodeStatusResult drops every group subscription once another node leads the topic,
+// telling their members to register with the topic on the leader's pod.
+// Their unacknowledged messages are delivered again from the group's committed offset there.
+func (topic *topicActor) handleNodeStatusResult(act *actor.Context, msg *NodeStatusResult) {
+	if msg.Leader == nil {
+		return
+	}
+	leader := PIDToActorPID(msg.Leader)
+	if leader.String() == topic.messagesPID.String() {
+		return
+	}
+	for _, sub := range topic.groups {
+		for _, member := range sub.members {
+			delete(topic.consumers, member.pid.LookupKey())
+			act.Send(member.pid, &ConsumerRemoved{
+				Topic:       topic.config.Topic,
+				Reason:      "not the leader",
+				RedirectPID: topicRedirect(msg.Leader),
+			})
+		}
+		delete(topic.subscriptions, sub.id)
+		delete(topic.groups, sub.group)
+		topic.config.Logger.Info("Removed group", "topic", topic.config.Topic, "group", sub.group, "leader", leader)
+	}
+	topic.updateRetention()
+}
+
+// handleSnapshotRestored makes the live subscriptions read the log again once the node has installed a snapshot,
+// as the messages it covers were never applied on this pod.
+func (topic *topicActor) handleSnapshotRestored(act *actor.Context) {
+	for _, sub := range topic.subscriptions {
+		if sub.live {
+			sub.live = false
+			topic.resume(act, sub)
+		}
+	}
+}
+
+// removeConsumer rebalances the consumer's subscription over its remaining members,
+// and drops the subscription once it has none.
+func (topic *topicActor) removeConsumer(act *actor.Context, key uint64) {
+	consumer := topic.consumers[key]
+	delete(topic.consumers, key)
+	sub := consumer.subscription
+	sub.members = slices.DeleteFunc(sub.members, func(member *consumerMetadata) bool {
+		return member == consumer
+	})
+	topic.config.Logger.Info("Removed consumer", "topic", topic.config.Topic, "consumer", consumer.pid, "group", sub.group, "members", len(sub.members))
+	if len(sub.members) == 0 {
+		delete(topic.subscriptions, sub.id)
+		if sub.group != "" {
+			delete(topic.groups, sub.group)
+		}
+		return
+	}
+	sub.nextMember %= len(sub.members)
+	for _, message := range sub.inflight {
+		if message.consumer == consumer {
+			message.consumer = nil
+		}
+	}
+	topic.redeliver(act, sub, "consumer removed", func(message *inflightMessage) bool {
+		return message.consumer == nil
+	})
+}
+
+// handleAck drops an acknowledged message from its subscription, making room for the next one.
+// Messages are delivered at least once, as every message that is not acknowledged is sent again.
+func (topic *topicActor) handleAck(act *actor.Context, msg *Ack) {
+	consumer, ok := topic.consumers[act.Sender().LookupKey()]
+	if !ok {
+		return
+	}
+	sub := consumer.subscription
+	message, ok := sub.inflight[msg.Offset]
+	if !ok {
+		return
+	}
+	delete(sub.inflight, msg.Offset)
+	if message.consumer != nil {
+		message.consumer.inflight--
+	}
+	// Messages left without a consumer go before the rest of the log
+	topic.redeliver(act, sub, "", func(message *inflightMessage) bool {
+		return message.consumer == nil
+	})
+	topic.resume(act, sub)
+}
+
+// handleNack records why a consumer could not process a message, which is sent again by the next check
+// or dead-lettered if it has run out of deliveries.
+func (topic *topicActor) handleNack(act *actor.Context, msg *Nack) {
+	consumer, ok := topic.consumers[act.Sender().LookupKey()]
+	if !ok {
+		return
+	}
+	sub := consumer.subscription
+	nacked, ok := sub.inflight[msg.Offset]
+	if !ok {
+		return
+	}
+	if nacked.consumer != nil {
+		nacked.consumer.inflight--
+		nacked.consumer = nil
+	}
+	nacked.reason = msg.Reason
+	if nacked.reason == "" {
+		nacked.reason = "rejected by consumer"
+	}
+	if !nacked.deadLetter && sub.deadLetterTopic != "" && nacked.deliveries >= sub.maxDeliveries {
+		topic.deadLetter(act, sub, nacked)
+	}
+	topic.resume(act, sub)
+}
+
+func (topic *topicActor) handleConsumerEnvelope(act *actor.Context, msg *ConsumerEnvelope) {
+	// Entries are applied again when the node restarts, so subscriptions only receive offsets they have not seen
+	for _, sub := range topic.subscriptions {
+		if sub.live && msg.Offset >= sub.next && !topic.deliver(act, sub, msg) {
+			// Every member is full, so the message is read from the log once there is room
+			sub.live = false
+		}
+	}
+}
+
+// deliver sends envelope to the next member of sub with room for it and reports whether there was one.
+func (topic *topicActor) deliver(act *actor.Context, sub *subscription, envelope *ConsumerEnvelope) bool {
+	member := nextMember(sub)
+	if member == nil {
+		return false
+	}
+	message := &inflightMessage{
+		envelope: envelope,
+	}
+	sub.inflight[envelope.Offset] = message
+	topic.send(act, member, message)
+	sub.next = envelope.Offset + 1
+	return true
+}
+
+// redeliver sends the in-flight messages of sub selected by resend again, in offset order,
+// for as long as its members have room for them. Messages that have run out of deliveries
+// are moved to the dead-letter topic instead. A non-empty reason records why the messages failed.
+func (topic *topicActor) redeliver(act *actor.Context, sub *subscription, reason string, resend func(message *inflightMessage) bool) {
+	offsets := make([]uint64, 0)
+	for offset, message := range sub.inflight {
+		if !message.deadLetter && resend(message) {
+			offsets = append(offsets, offset)
+		}
+	}
+	slices.Sort(offsets)
+	for _, offset := range offsets {
+		message := sub.inflight[offset]
+		if reason != "" {
+			message.reason = reason
+		}
+		if sub.deadLetterTopic != "" && message.deliveries >= sub.maxDeliveries {
+			topic.deadLetter(act, sub, message)
+			continue
+		}
+		if message.consumer != nil {
+			message.consumer.inflight--
+		}
+		member := nextMember(sub)
+		if member == nil {
+			if message.consumer != nil {
+				message.consumer.inflight++
+			}
+			return
+		}
+		topic.send(act, member, message)
+	}
+}
+
+// deadLetter hands message to the dead-letter publisher, keeping it in flight until the dead-letter topic has committed it.
+func (topic *topicActor) deadLetter(act *actor.Context, sub *subscription, message *inflightMessage) {
+	if message.consumer != nil {
+		message.consumer.inflight--
+		message.consumer = nil
+	}
+	message.deadLetter = true
+	act.Send(topic.deadLetterPID, &deadLetterRequest{
+		subscription: sub.id,
+		topic:        sub.deadLetterTopic,
+		deadLetter: &DeadLetter{
+			Topic:           topic.config.Topic,
+			Group:           sub.group,
+			Offset:          message.envelope.Offset,
+			Reason:          message.reason,
+			Deliveries:      message.deliveries,
+			Message:         message.envelope.Message,
+			AppendTimestamp: message.envelope.AppendTimestamp,
+		},
+	})
+	topic.config.Logger.Info("Dead-lettering message", "topic", topic.config.Topic, "group", sub.group, "offset", message.envelope.Offset, "deadLetterTopic", sub.deadLetterTopic, "reason", message.reason)
+}
+
+// handleDeadLetterResult drops a message from its subscription once it has been dead-lettered.
+// A message that could not be dead-lettered is left without a consumer, and is dead-lettered again by the next check.
+func (topic *topicActor) handleDeadLetterResult(act *actor.Context, msg *deadLetterResult) {
+	sub, ok := topic.subscriptions[msg.subscription]
+	if !ok {
+		return
+	}
+	message, ok := sub.inflight[msg.offset]
+	if !ok || !message.deadLetter {
+		return
+	}
+	message.deadLetter = false
+	if msg.err != nil {
+		topic.config.Logger.Error("Dead-lettering message", "topic", topic.config.Topic, "group", sub.group, "offset", msg.offset, "error", msg.err)
+		return
+	}
+	delete(sub.inflight, msg.offset)
+	topic.resume(act, sub)
+}
+
+func (topic *topicActor) send(act *actor.Context, member *consumerMetadata, message *inflightMessage) {
+	message.consumer = member
+	message.deadline = time.Now().Add(member.ackTimeout)
+	message.deliveries++
+	member.inflight++
+	act.Send(member.pid, &ConsumerEnvelope{
+		Message:         message.envelope.Message,
+		Offset:          message.envelope.Offset,
+		Term:            message.envelope.Term,
+		AppendTimestamp: message.envelope.AppendTimestamp,
+		Deliveries:      message.deliveries,
+	})
+}
+
+// nextMember returns the next member of sub in turn that has room for another message, if any.
+func nextMember(sub *subscription) *consumerMetadata {
+	for i := range sub.members {
+		member := sub.members[(sub.nextMember+i)%len(sub.members)]
+		if member.inflight < member.maxInFlight {
+			sub.nextMember = (sub.nextMember + i + 1) % len(sub.members)
+			return member
+		}
+	}
+	return nil
+}
+
+// resume reads the log again for a subscription that has fallen behind it.
+func (topic *topicActor) resume(act *actor.Context, sub *subscription) {
+	if !sub.live && !sub.reading {
+		topic.readLog(act, sub)
+	}
+}
+
+// readLog requests the next entries a replaying subscription has not received from the node.
+// The node applies entries and answers reads in order, so every message applied before the
+// result arrives is either in the result or at an offset the subscription has already been sent.
+func (topic *topicActor) readLog(act *actor.Context, sub *subscription) {
+	sub.reading = true
+	sub.readFailed = false
+	act.Send(topic.messagesPID, &ReadLog{
+		Id:         sub.id,
+		From:       sub.next,
+		MaxEntries: replayBatchSize,
+	})
+}
+
+// handleReadLogResult delivers the entries read for a replaying subscription, which goes live once it has
+// caught up with the node. Messages that were compacted before it read them are skipped, and its members
+// are told with MessagesCompacted.
+func (topic *topicActor) handleReadLogResult(act *actor.Context, msg *ReadLogResult) {
+	sub, ok := topic.subscriptions[msg.Id]
+	if !ok || sub.live {
+		return
+	}
+	sub.reading = false
+	if msg.FirstAvailable > sub.next && sub.next > 0 {
+		topic.config.Logger.Warn("Messages compacted before delivery", "topic", topic.config.Topic, "group", sub.group, "from", sub.next, "next", msg.FirstAvailable)
+		for _, member := range sub.members {
+			act.Send(member.pid, &MessagesCompacted{
+				Topic: topic.config.Topic,
+				From:  sub.next,
+				Next:  msg.FirstAvailable,
+			})
+		}
+		sub.next = msg.FirstAvailable
+	}
+	for _, envelope := range msg.Envelopes {
+		if envelope.Offset >= sub.next && !topic.deliver(act, sub, envelope) {
+			// Reading continues from the undelivered message once there is room
+			return
+		}
+	}
+	sub.next = max(sub.next, msg.Next)
+	if msg.Error != "" {
+		// Going live would skip the entries that could not be read, so the next check reads them again
+		topic.config.Logger.Error("Replaying log", "topic", topic.config.Topic, "group", sub.group, "error", msg.Error)
+		sub.readFailed = true
+		return
+	}
+	if sub.next > msg.LastApplied {
+		sub.live = true
+		return
+	}
+	topic.readLog(act, sub)
+}
+
+// handleFetchOffset reads a group's committed offset from the leader with a ReadIndex.
+func (topic *topicActor) handleFetchOffset(act *actor.Context, msg *FetchOffset) {
+	topic.nextReadID++
+	topic.pendingFetches[topic.nextReadID] = &fetchMetadata{
+		sender: act.Sender(),
+		group:  msg.Group,
+	}
+	act.Send(topic.messagesPID, &ReadIndex{
+		Topic: topic.config.Topic,
+		Id:    topic.nextReadID,
+	})
+}
+
+// handleReadIndexResult answers a FetchOffset or registers a group member once the node has applied
+// every offset committed before it arrived.
+// A node that is not the leader redirects to the topic on the leader's pod.
+func (topic *topicActor) handleReadIndexResult(act *actor.Context, msg *ReadIndexResult) {
+	redirectPID := topicRedirect(msg.RedirectPID)
+	if registration, ok := topic.pendingRegistrations[msg.Id]; ok {
+		delete(topic.pendingRegistrations, msg.Id)
+		if !msg.Success {
+			act.Send(registration.sender, &RegisterConsumerResult{
+				Success:     false,
+				Error:       msg.Error,
+				RedirectPID: redirectPID,
+			})
+			return
+		}
+		topic.registerConsumer(act, registration.sender, registration.request)
+		return
+	}
+	fetch, ok := topic.pendingFetches[msg.Id]
+	if !ok {
+		return
+	}
+	delete(topic.pendingFetches, msg.Id)
+	if !msg.Success {
+		act.Send(fetch.sender, &FetchOffsetResult{
+			Success:     false,
+			Error:       msg.Error,
+			RedirectPID: redirectPID,
+		})
+		return
+	}
+	offset, committed := topic.stateMachine.Offset(fetch.group)
+	act.Send(fetch.sender, &FetchOffsetResult{
+		Success:   true,
+		Offset:    offset,
+		Committed: committed,
+	})
+}
+
+// topicRedirect returns the topic on the pod of the node leader, which is where consumers are redirected to,
+// or nil if the leader is not known.
+func topicRedirect(leader *PID) *PID {
+	if leader == nil {
+		return nil
+	}
+	return ActorPIDToPID(ParentPID(PIDToActorPID(leader)))
+}
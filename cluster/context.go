@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/anthdm/hollywood/actor"
+	"github.com/troygilman/actormq/cluster/timer"
+)
+
+// NodeContext is everything a RaftNode needs from the runtime driving it.
+type NodeContext interface {
+	// PID returns the PID of the node.
+	PID() *actor.PID
+	// Sender returns the sender of the message being handled.
+	Sender() *actor.PID
+	// Send delivers msg to pid with the node as its sender.
+	Send(pid *actor.PID, msg any)
+	// Now returns the current time.
+	Now() time.Time
+	// Rand returns the source of randomness for election timeouts.
+	Rand() *rand.Rand
+	// SetTimer delivers msg to the node after d,
+	// replacing the timer previously set for a message of the same type.
+	SetTimer(msg any, d time.Duration)
+}
+
+// nodeActor runs a RaftNode on the actor engine with real timers.
+type nodeActor struct {
+	node   *RaftNode
+	rand   *rand.Rand
+	timers map[reflect.Type]*timer.SendTimer
+}
+
+func NewNode(config NodeConfig) actor.Producer {
+	return func() actor.Receiver {
+		return &nodeActor{
+			node:   NewRaftNode(config),
+			rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+			timers: make(map[reflect.Type]*timer.SendTimer),
+		}
+	}
+}
+
+func (a *nodeActor) Receive(act *actor.Context) {
+	switch act.Message().(type) {
+	case actor.Started:
+		// Without a state machine committed messages are forwarded to the parent
+		if a.node.config.StateMachine == nil {
+			a.node.config.StateMachine = NewConsumerStateMachine(act.Engine(), act.Parent())
+		}
+
+	case actor.Stopped:
+		for key, t := range a.timers {
+			t.Stop()
+			delete(a.timers, key)
+		}
+	}
+	a.node.Step(&actorNodeContext{
+		act:   act,
+		actor: a,
+	}, act.Message())
+}
+
+type actorNodeContext struct {
+	act   *actor.Context
+	actor *nodeActor
+}
+
+func (ctx *actorNodeContext) PID() *actor.PID {
+	return ctx.act.PID()
+}
+
+func (ctx *actorNodeContext) Sender() *actor.PID {
+	return ctx.act.Sender()
+}
+
+func (ctx *actorNodeContext) Send(pid *actor.PID, msg any) {
+	ctx.act.Send(pid, msg)
+}
+
+func (ctx *actorNodeContext) Now() time.Time {
+	return time.Now()
+}
+
+func (ctx *actorNodeContext) Rand() *rand.Rand {
+	return ctx.actor.rand
+}
+
+func (ctx *actorNodeContext) SetTimer(msg any, d time.Duration) {
+	key := reflect.TypeOf(msg)
+	if t, ok := ctx.actor.timers[key]; ok {
+		t.Reset(d)
+		return
+	}
+	ctx.actor.timers[key] = timer.NewSendTimer(ctx.act.Engine(), ctx.act.PID(), msg, d)
+}
@@ -0,0 +1,214 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anthdm/hollywood/actor"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	deadLetterRequest struct {
+		subscription uint64
+		topic        string
+		deadLetter   *DeadLetter
+	}
+	deadLetterResult struct {
+		subscription uint64
+		offset       uint64
+		err          error
+	}
+)
+
+// publisher publishes messages to any topic through the local pod, following redirects to each topic's leader.
+type publisher struct {
+	pod     *actor.PID
+	leaders map[string]*actor.PID
+}
+
+func newPublisher(pod *actor.PID) *publisher {
+	return &publisher{
+		pod:     pod,
+		leaders: make(map[string]*actor.PID),
+	}
+}
+
+// send publishes envelope to its topic with the producer ID and sequence number it already carries.
+func (p *publisher) send(act *actor.Context, envelope *Envelope) (*EnvelopeResult, error) {
+	topic := envelope.Topic
+	for {
+		pid, ok := p.leaders[topic]
+		if !ok {
+			pid = p.pod
+		}
+		response, err := act.Request(pid, envelope, 10*time.Second).Result()
+		if err != nil {
+			delete(p.leaders, topic)
+			return nil, err
+		}
+		result, ok := response.(*EnvelopeResult)
+		if !ok {
+			return nil, errors.New("unexpected response to envelope")
+		}
+		if !result.Success {
+			if result.RedirectPID != nil {
+				p.leaders[topic] = PIDToActorPID(result.RedirectPID)
+				continue
+			}
+			delete(p.leaders, topic)
+			if result.Error == "" {
+				return nil, errors.New("topic has no leader")
+			}
+			return nil, errors.New(result.Error)
+		}
+		return result, nil
+	}
+}
+
+// deadLetterActor publishes dead letters for its parent topic and reports the outcome back to it.
+// It is a child of the topic as publishing waits for the dead-letter topic to commit each message.
+// Each dead letter is published with a producer ID derived from the topic, group and offset it came from,
+// so dead-lettering a message again after a failure or a restart is dropped as a duplicate.
+// A group's messages run out of deliveries in any order, so they cannot share a producer ID
+// with their offsets as sequence numbers.
+type deadLetterActor struct {
+	publisher *publisher
+}
+
+func newDeadLetterActor(pod *actor.PID) actor.Producer {
+	return func() actor.Receiver {
+		return &deadLetterActor{
+			publisher: newPublisher(pod),
+		}
+	}
+}
+
+func (d *deadLetterActor) Receive(act *actor.Context) {
+	switch msg := act.Message().(type) {
+	case *deadLetterRequest:
+		result := &deadLetterResult{
+			subscription: msg.subscription,
+			offset:       msg.deadLetter.Offset,
+		}
+		data, err := proto.Marshal(msg.deadLetter)
+		if err == nil {
+			_, err = d.publisher.send(act, &Envelope{
+				Topic: msg.topic,
+				Message: &Message{
+					TypeName: string(proto.MessageName(msg.deadLetter)),
+					Data:     data,
+				},
+				ProducerID: fmt.Sprintf("deadletter/%s/%s/%d", msg.deadLetter.Topic, msg.deadLetter.Group, msg.deadLetter.Offset),
+				Sequence:   1,
+			})
+		}
+		result.err = err
+		act.Send(act.Parent(), result)
+	}
+}
+
+// redriveActor answers a Redrive by reading the dead-letter topic's log from its own node and publishing
+// every DeadLetter in it back to its original topic. It stops once it has answered.
+// A Redrive that continues from an offset that has since been compacted fails with Next set to the first offset left.
+// Each message is published with a producer ID derived from the dead-letter topic and its offset in it as the
+// sequence number, so running a Redrive again over the same offsets drops the messages already published
+// as duplicates, for as long as the destination topic remembers the producer. Messages before the last offset
+// redriven to a topic are dropped the same way, so redrives of a dead-letter topic should move forward through it.
+// Dropped messages are counted in Duplicates rather than Count.
+type redriveActor struct {
+	publisher  *publisher
+	node       *actor.PID
+	sender     *actor.PID
+	request    *Redrive
+	result     *RedriveResult
+	producerID string
+}
+
+func newRedriveActor(pod *actor.PID, node *actor.PID, sender *actor.PID, request *Redrive) actor.Producer {
+	return func() actor.Receiver {
+		return &redriveActor{
+			publisher: newPublisher(pod),
+			node:      node,
+			sender:    sender,
+			request:   request,
+			result: &RedriveResult{
+				Next: request.From,
+			},
+			producerID: "redrive/" + request.Topic,
+		}
+	}
+}
+
+func (r *redriveActor) Receive(act *actor.Context) {
+	switch msg := act.Message().(type) {
+	case actor.Started:
+		r.readLog(act)
+
+	case *ReadLogResult:
+		if msg.Error != "" {
+			r.finish(act, errors.New(msg.Error))
+			return
+		}
+		if msg.FirstAvailable > r.result.Next && r.result.Next > 0 {
+			err := fmt.Errorf("dead letters from offset %d to %d have been compacted", r.result.Next, msg.FirstAvailable-1)
+			r.result.Next = msg.FirstAvailable
+			r.finish(act, err)
+			return
+		}
+		for _, envelope := range msg.Envelopes {
+			if r.request.MaxMessages > 0 && r.result.Count+r.result.Duplicates >= r.request.MaxMessages {
+				r.finish(act, nil)
+				return
+			}
+			if envelope.Message.TypeName == string(proto.MessageName(&DeadLetter{})) {
+				deadLetter := &DeadLetter{}
+				if err := proto.Unmarshal(envelope.Message.Data, deadLetter); err != nil {
+					r.finish(act, err)
+					return
+				}
+				result, err := r.publisher.send(act, &Envelope{
+					Topic:      deadLetter.Topic,
+					Message:    deadLetter.Message,
+					ProducerID: r.producerID,
+					Sequence:   envelope.Offset,
+				})
+				if err != nil {
+					r.finish(act, err)
+					return
+				}
+				// A duplicate of anything but the producer's last message is acknowledged without an index
+				if result.Index == 0 {
+					r.result.Duplicates++
+				} else {
+					r.result.Count++
+				}
+			}
+			r.result.Next = envelope.Offset + 1
+		}
+		r.result.Next = max(r.result.Next, msg.Next)
+		if r.result.Next > msg.LastApplied {
+			r.finish(act, nil)
+			return
+		}
+		r.readLog(act)
+	}
+}
+
+func (r *redriveActor) readLog(act *actor.Context) {
+	act.Send(r.node, &ReadLog{
+		From:       r.result.Next,
+		MaxEntries: replayBatchSize,
+	})
+}
+
+// finish answers the Redrive with the number of messages published and the offset to continue from.
+func (r *redriveActor) finish(act *actor.Context, err error) {
+	r.result.Success = err == nil
+	if err != nil {
+		r.result.Error = err.Error()
+	}
+	act.Send(r.sender, r.result)
+	act.Engine().Poison(act.PID())
+}
@@ -13,6 +13,7 @@ type (
 	discoveryNodeMetadata struct {
 		pid      *actor.PID
 		lastPong time.Time
+		learner  bool
 	}
 )
 
@@ -55,6 +56,7 @@ func (d *discoveryActor) Receive(act *actor.Context) {
 		d.nodes[pid.LookupKey()] = &discoveryNodeMetadata{
 			pid:      pid,
 			lastPong: time.Now(),
+			learner:  msg.Learner,
 		}
 		d.sendActiveNodes(act, msg.Topic)
 		log.Println("registered node", pid.String(), "on topic", msg.Topic)
@@ -90,12 +92,18 @@ func (d *discoveryActor) Receive(act *actor.Context) {
 
 func (d *discoveryActor) sendActiveNodes(act *actor.Context, topic string) {
 	nodes := make([]*PID, 0)
+	learners := make([]*PID, 0)
 	for key := range d.topics[topic] {
-		nodes = append(nodes, ActorPIDToPID(d.nodes[key].pid))
+		if d.nodes[key].learner {
+			learners = append(learners, ActorPIDToPID(d.nodes[key].pid))
+		} else {
+			nodes = append(nodes, ActorPIDToPID(d.nodes[key].pid))
+		}
 	}
 	for key := range d.topics[topic] {
 		act.Send(d.nodes[key].pid, &ActiveNodes{
-			Nodes: nodes,
+			Nodes:    nodes,
+			Learners: learners,
 		})
 	}
 }
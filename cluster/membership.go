@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"github.com/anthdm/hollywood/actor"
+)
+
+// handleActiveNodes records the nodes the discovery actor believes to be alive.
+// They only replace the configuration while bootstrapping;
+// afterwards the leader turns the difference into configuration changes.
+func (node *RaftNode) handleActiveNodes(act NodeContext, msg *ActiveNodes) {
+	node.activeNodes = msg
+	if node.configurationIndex == 0 {
+		node.setConfiguration(act, &Configuration{
+			Voters:   msg.Nodes,
+			Learners: msg.Learners,
+		}, 0)
+	}
+	node.config.Logger.Info("handleActiveNodes", "msg", msg, "nodes", node.nodes)
+}
+
+// updateConfiguration proposes the next configuration change needed to match the active nodes,
+// one server at a time as in the Raft dissertation, and only once the previous change has committed.
+// New voters join as learners and are promoted once they have caught up with the commit index.
+func (node *RaftNode) updateConfiguration(act NodeContext) {
+	if !pidEquals(node.leader, act.PID()) || node.configurationIndex > node.commitIndex || node.activeNodes == nil {
+		return
+	}
+
+	voters := node.configuration.GetVoters()
+	learners := node.configuration.GetLearners()
+
+	// Add new servers as learners so they catch up before counting towards a majority
+	for _, pid := range append(node.activeNodes.GetNodes(), node.activeNodes.GetLearners()...) {
+		if !containsPID(voters, pid) && !containsPID(learners, pid) {
+			node.proposeConfiguration(act, &Configuration{
+				Voters:   voters,
+				Learners: appendPID(learners, pid),
+			})
+			return
+		}
+	}
+
+	// Promote learners that should be voters once they have caught up
+	for _, pid := range learners {
+		if !containsPID(node.activeNodes.GetNodes(), pid) {
+			continue
+		}
+		metadata, ok := node.nodes[PIDToActorPID(pid).LookupKey()]
+		if ok && metadata.matchIndex >= node.commitIndex {
+			node.proposeConfiguration(act, &Configuration{
+				Voters:   appendPID(voters, pid),
+				Learners: removePID(learners, pid),
+			})
+			return
+		}
+	}
+
+	for _, pid := range voters {
+		if !containsPID(node.activeNodes.GetNodes(), pid) {
+			node.proposeConfiguration(act, &Configuration{
+				Voters:   removePID(voters, pid),
+				Learners: learners,
+			})
+			return
+		}
+	}
+	for _, pid := range learners {
+		if !containsPID(node.activeNodes.GetNodes(), pid) && !containsPID(node.activeNodes.GetLearners(), pid) {
+			node.proposeConfiguration(act, &Configuration{
+				Voters:   voters,
+				Learners: removePID(learners, pid),
+			})
+			return
+		}
+	}
+}
+
+// proposeConfiguration appends configuration to the log and switches to it immediately,
+// as a configuration takes effect as soon as its entry is in the log.
+func (node *RaftNode) proposeConfiguration(act NodeContext, configuration *Configuration) {
+	entry := &LogEntry{
+		Term:          node.currentTerm,
+		Configuration: configuration,
+	}
+	if err := setChecksums(entry); err != nil {
+		node.config.Logger.Error("proposeConfiguration", "pid", act.PID(), "error", err)
+		return
+	}
+	if err := node.store.Append(entry); err != nil {
+		node.config.Logger.Error("proposeConfiguration", "pid", act.PID(), "error", err)
+		return
+	}
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	node.setConfiguration(act, configuration, lastLogIndex)
+	node.config.Logger.Info("Proposed configuration", "pid", act.PID(), "index", lastLogIndex, "configuration", configuration)
+	node.sendAppendEntriesAll(act, false)
+}
+
+// reloadConfiguration switches to the latest configuration in the log or snapshot,
+// falling back to the active nodes if there is none.
+func (node *RaftNode) reloadConfiguration(act NodeContext) error {
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	configuration, index, err := node.configurationAt(lastLogIndex)
+	if err != nil {
+		return err
+	}
+	if configuration == nil {
+		configuration = &Configuration{
+			Voters:   node.activeNodes.GetNodes(),
+			Learners: node.activeNodes.GetLearners(),
+		}
+	}
+	node.setConfiguration(act, configuration, index)
+	return nil
+}
+
+// configurationAt returns the latest configuration at or before index and the index of its entry.
+func (node *RaftNode) configurationAt(index uint64) (*Configuration, uint64, error) {
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		return nil, 0, err
+	}
+	if index > snapshot.LastIncludedIndex {
+		entries, err := node.store.Entries(snapshot.LastIncludedIndex+1, index+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].Configuration != nil {
+				return entries[i].Configuration, snapshot.LastIncludedIndex + uint64(i) + 1, nil
+			}
+		}
+	}
+	if snapshot.Configuration != nil {
+		return snapshot.Configuration, snapshot.LastIncludedIndex, nil
+	}
+	return nil, 0, nil
+}
+
+// setConfiguration replaces the set of servers the node replicates to and counts votes from.
+func (node *RaftNode) setConfiguration(act NodeContext, configuration *Configuration, index uint64) {
+	node.configuration = configuration
+	node.configurationIndex = index
+
+	nodes := make(map[uint64]*nodeMetadata)
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	for _, pid := range append(append([]*PID{}, configuration.Voters...), configuration.Learners...) {
+		pid := PIDToActorPID(pid)
+		if pidEquals(pid, act.PID()) {
+			continue
+		}
+		key := pid.LookupKey()
+		metadata, ok := node.nodes[key]
+		if !ok {
+			metadata = &nodeMetadata{
+				pid:          pid,
+				nextIndex:    lastLogIndex + 1,
+				matchIndex:   0,
+				lastResponse: act.Now(),
+			}
+		}
+		metadata.learner = !containsPID(configuration.Voters, ActorPIDToPID(pid))
+		nodes[key] = metadata
+	}
+	node.nodes = nodes
+}
+
+// isVoter reports whether pid votes in the current configuration.
+// Learners receive the log like voters but never vote and are not counted towards a majority.
+func (node *RaftNode) isVoter(pid *actor.PID) bool {
+	return containsPID(node.configuration.GetVoters(), ActorPIDToPID(pid))
+}
+
+// quorum returns the number of voters that make up a majority of the configuration.
+func (node *RaftNode) quorum() int {
+	return len(node.configuration.GetVoters())/2 + 1
+}
+
+// appendPID returns a copy of pids with pid added.
+func appendPID(pids []*PID, pid *PID) []*PID {
+	return append(append([]*PID{}, pids...), pid)
+}
+
+// removePID returns a copy of pids without pid.
+func removePID(pids []*PID, pid *PID) []*PID {
+	result := []*PID{}
+	for _, p := range pids {
+		if !pidEquals(PIDToActorPID(p), PIDToActorPID(pid)) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func containsPID(pids []*PID, pid *PID) bool {
+	for _, p := range pids {
+		if pidEquals(PIDToActorPID(p), PIDToActorPID(pid)) {
+			return true
+		}
+	}
+	return false
+}
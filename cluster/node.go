@@ -3,15 +3,17 @@ package cluster
 import (
 	"errors"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/anthdm/hollywood/actor"
-	"github.com/troygilman/actormq/cluster/timer"
+	"google.golang.org/protobuf/proto"
 )
 
 type (
 	heartbeatTimeout struct{}
 	electionTimeout  struct{}
+	batchTimeout     struct{}
 )
 
 type NodeConfig struct {
@@ -22,6 +24,22 @@ type NodeConfig struct {
 	ElectionMinInterval time.Duration
 	ElectionMaxInterval time.Duration
 	HeartbeatInterval   time.Duration
+	SnapshotThreshold   uint64
+	MaxRetainedEntries  uint64
+	Learner             bool
+	MaxAppendEntries    uint64
+	MaxAppendBytes      int
+	MaxInflightAppends  int
+	CommandTimeout      time.Duration
+	BatchInterval       time.Duration
+	MaxBatchSize        int
+	ReadLease           bool
+	ClockDriftMargin    time.Duration
+	ProducerTTL         time.Duration
+	LogStore            LogStoreProducer
+	StateMachine        StateMachine
+	Transport           *actor.PID
+	Observer            NodeObserver
 }
 
 func NewNodeConfig() NodeConfig {
@@ -30,6 +48,18 @@ func NewNodeConfig() NodeConfig {
 		ElectionMinInterval: 150 * time.Millisecond,
 		ElectionMaxInterval: 300 * time.Millisecond,
 		HeartbeatInterval:   50 * time.Millisecond,
+		SnapshotThreshold:   1024,
+		MaxRetainedEntries:  16 * 1024,
+		MaxAppendEntries:    256,
+		MaxAppendBytes:      1 << 20,
+		MaxInflightAppends:  4,
+		CommandTimeout:      5 * time.Second,
+		BatchInterval:       2 * time.Millisecond,
+		MaxBatchSize:        128,
+		ClockDriftMargin:    15 * time.Millisecond,
+		ProducerTTL:         24 * time.Hour,
+		LogStore:            NewMemoryLogStore(),
+		Observer:            defaultObserver,
 	}
 }
 
@@ -43,39 +73,93 @@ func (config NodeConfig) WithLogger(logger *slog.Logger) NodeConfig {
 	return config
 }
 
-type nodeActor struct {
-	config            NodeConfig
-	leader            *actor.PID
-	currentTerm       uint64
-	votedFor          *actor.PID
-	log               []*LogEntry
-	commitIndex       uint64
-	lastApplied       uint64
-	votes             uint64
-	nodes             map[uint64]*nodeMetadata
-	pendingCommands   map[uint64]*commandMetadata
-	heartbeatRepeater actor.SendRepeater
-	electionTimer     *timer.SendTimer
+func (config NodeConfig) WithLogStore(logStore LogStoreProducer) NodeConfig {
+	config.LogStore = logStore
+	return config
 }
 
-func NewNode(config NodeConfig) actor.Producer {
-	return func() actor.Receiver {
-		return &nodeActor{
-			config: config,
-		}
-	}
+func (config NodeConfig) WithStateMachine(stateMachine StateMachine) NodeConfig {
+	config.StateMachine = stateMachine
+	return config
+}
+
+// RaftNode is the Raft state of a single server in a topic's group.
+// It only interacts with the outside world through the NodeContext passed to Step,
+// so it can be driven by an actor or by a simulator.
+type RaftNode struct {
+	config             NodeConfig
+	pid                *actor.PID
+	leader             *actor.PID
+	currentTerm        uint64
+	votedFor           *actor.PID
+	store              LogStore
+	commitIndex        uint64
+	lastApplied        uint64
+	votes              uint64
+	preVotes           map[uint64]bool
+	lastLeaderContact  time.Time
+	nodes              map[uint64]*nodeMetadata
+	activeNodes        *ActiveNodes
+	configuration      *Configuration
+	configurationIndex uint64
+	pendingCommands    map[uint64]*commandMetadata
+	producers          map[string]*ProducerState
+	duplicates         map[uint64]bool
+	pendingReads       []*readMetadata
+	batch              []*batchedCommand
+	readSeq            uint64
+	heartbeats         []heartbeatRound
+	transfer           *leadershipTransfer
+	checksumFailures   uint64
+	lastChecksumError  string
+	repairing          bool
+	pendingSnapshot    *Snapshot
 }
 
-func (node *nodeActor) Receive(act *actor.Context) {
-	switch msg := act.Message().(type) {
-	case actor.Initialized:
-		node.nodes = make(map[uint64]*nodeMetadata)
-		node.pendingCommands = make(map[uint64]*commandMetadata)
+func NewRaftNode(config NodeConfig) *RaftNode {
+	return &RaftNode{
+		config:          config,
+		nodes:           make(map[uint64]*nodeMetadata),
+		pendingCommands: make(map[uint64]*commandMetadata),
+		preVotes:        make(map[uint64]bool),
+		producers:       make(map[string]*ProducerState),
+		duplicates:      make(map[uint64]bool),
+	}
+}
 
+// Step handles a single message, starting with actor.Started and ending with actor.Stopped.
+func (node *RaftNode) Step(act NodeContext, msg any) {
+	switch msg := msg.(type) {
 	case actor.Started:
-		node.electionTimer = timer.NewSendTimer(act.Engine(), act.PID(), electionTimeout{}, newElectionTimoutDuration(node.config))
-		node.heartbeatRepeater = act.SendRepeat(act.PID(), heartbeatTimeout{}, node.config.HeartbeatInterval)
-		act.Send(node.config.DiscoveryPID, &RegisterNode{Topic: node.config.Topic})
+		node.pid = act.PID()
+		if err := node.restore(); err != nil {
+			node.config.Logger.Error("Restoring node", "pid", act.PID(), "error", err)
+			panic(err)
+		}
+		if store, ok := node.store.(RecoveringLogStore); ok && store.RecoveryError() != nil {
+			node.recordChecksumFailure(act, store.RecoveryError())
+			node.repairing = true
+		}
+		if err := node.reloadConfiguration(act); err != nil {
+			panic(err)
+		}
+		if node.configurationIndex > 0 && !node.isVoter(act.PID()) && !containsPID(node.configuration.GetLearners(), ActorPIDToPID(act.PID())) {
+			// The configuration refers to servers by PID, so a node restarted under another PID is a stranger to it
+			node.config.Logger.Warn("Not in the restored configuration", "pid", act.PID(), "configuration", node.configuration)
+		}
+		node.resetElectionTimer(act)
+		act.SetTimer(heartbeatTimeout{}, node.config.HeartbeatInterval)
+		act.Send(node.config.DiscoveryPID, &RegisterNode{
+			Topic:   node.config.Topic,
+			Learner: node.config.Learner,
+		})
+
+	case actor.Stopped:
+		if node.store != nil {
+			if err := node.store.Close(); err != nil {
+				node.config.Logger.Error("Closing log store", "pid", act.PID(), "error", err)
+			}
+		}
 
 	case *ActiveNodes:
 		node.handleActiveNodes(act, msg)
@@ -86,85 +170,322 @@ func (node *nodeActor) Receive(act *actor.Context) {
 	case *Envelope:
 		node.handleEnvelope(act, msg)
 
+	case *TransferLeadership:
+		node.handleTransferLeadership(act, msg)
+
+	case *ReadIndex:
+		node.handleReadIndex(act, msg)
+
+	case *NodeStatus:
+		node.handleNodeStatus(act, msg)
+
+	case *ReadLog:
+		node.handleReadLog(act, msg)
+
+	case *CommitOffset:
+		node.handleCommitOffset(act, msg)
+
+	case *TimeoutNow:
+		node.handleExternalTerm(act, msg.Term)
+		node.handleTimeoutNow(act, msg)
+
 	case *AppendEntries:
-		node.handleExternalTerm(msg.Term)
+		node.handleExternalTerm(act, msg.Term)
 		node.handleAppendEntries(act, msg)
 
 	case *AppendEntriesResult:
-		node.handleExternalTerm(msg.Term)
+		node.handleExternalTerm(act, msg.Term)
 		node.handleAppendEntriesResult(act, msg)
 
+	case *InstallSnapshot:
+		node.handleExternalTerm(act, msg.Term)
+		node.handleInstallSnapshot(act, msg)
+
+	case *InstallSnapshotResult:
+		node.handleExternalTerm(act, msg.Term)
+		node.handleInstallSnapshotResult(act, msg)
+
+	case *PreVote:
+		node.handlePreVote(act, msg)
+
+	case *PreVoteResult:
+		node.handlePreVoteResult(act, msg)
+
 	case *RequestVote:
-		node.handleExternalTerm(msg.Term)
+		node.handleExternalTerm(act, msg.Term)
 		node.handleRequestVote(act, msg)
 
 	case *RequestVoteResult:
-		node.handleExternalTerm(msg.Term)
+		node.handleExternalTerm(act, msg.Term)
 		node.handleRequestVoteResult(act, msg)
 
 	case electionTimeout:
-		node.electionTimer.Reset(newElectionTimoutDuration(node.config))
-		if !pidEquals(act.PID(), node.leader) {
-			node.startElection(act)
+		node.resetElectionTimer(act)
+		if !pidEquals(act.PID(), node.leader) && !node.awaitingRepair() {
+			node.startPreVote(act)
 		}
 
+	case batchTimeout:
+		node.flushBatch(act)
+
 	case heartbeatTimeout:
+		act.SetTimer(heartbeatTimeout{}, node.config.HeartbeatInterval)
 		if pidEquals(node.leader, act.PID()) {
-			node.sendAppendEntriesAll(act)
+			node.checkQuorum(act)
+		}
+		if pidEquals(node.leader, act.PID()) {
+			node.sendAppendEntriesAll(act, true)
 		}
 	}
 
 	node.updateStateMachine(act)
-}
+	node.updatePendingCommands(act)
+	node.updateReads(act)
+	node.updateConfiguration(act)
+	node.updateLeadershipTransfer(act)
 
-func (node *nodeActor) handleActiveNodes(act *actor.Context, msg *ActiveNodes) {
-	node.nodes = make(map[uint64]*nodeMetadata)
-	lastLogIndex, _ := node.lastLogIndexAndTerm()
-	for _, pid := range msg.Nodes {
-		pid := PIDToActorPID(pid)
-		if !pidEquals(pid, act.PID()) {
-			key := pid.LookupKey()
-			if _, ok := node.nodes[key]; !ok {
-				node.nodes[key] = &nodeMetadata{
-					pid:        pid,
-					nextIndex:  lastLogIndex + 1,
-					matchIndex: 0,
-				}
-			}
-		}
+	if node.config.Observer != nil {
+		node.config.Observer.Observe(node)
 	}
-	node.config.Logger.Info("handleActiveNodes", "msg", msg, "nodes", node.nodes)
 }
 
-func (node *nodeActor) handleEnvelope(act *actor.Context, msg *Envelope) {
+func (node *RaftNode) handleEnvelope(act NodeContext, msg *Envelope) {
 	node.config.Logger.Info("handleMessage", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	if node.rejectCommand(act, false) || node.handleDuplicateEnvelope(act, msg) {
+		return
+	}
+	node.propose(act, &batchedCommand{
+		sender:   act.Sender(),
+		envelope: msg,
+	})
+}
+
+// handleCommitOffset appends a consumer group's offset to the log, so that it is applied by the state machine
+// of every node and survives the loss of any of them.
+func (node *RaftNode) handleCommitOffset(act NodeContext, msg *CommitOffset) {
+	node.config.Logger.Info("handleCommitOffset", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	if node.rejectCommand(act, true) {
+		return
+	}
+	node.propose(act, &batchedCommand{
+		sender: act.Sender(),
+		offsetCommit: &OffsetCommit{
+			Group:  msg.Group,
+			Offset: msg.Offset,
+		},
+	})
+}
+
+// rejectCommand answers a command this node cannot append to its log and reports whether it did.
+// The command is redirected to the target of a leadership transfer in progress, or to the leader
+// when this node is not the leader, or has just found out that it no longer has a quorum.
+func (node *RaftNode) rejectCommand(act NodeContext, offsetCommit bool) bool {
+	result := &EnvelopeResult{
+		Success: false,
+	}
+	if node.transfer != nil {
+		result.Error = "leadership transfer in progress"
+		result.RedirectPID = ActorPIDToPID(node.transfer.target)
+		act.Send(act.Sender(), commandResult(offsetCommit, result))
+		return true
+	}
 	if pidEquals(node.leader, act.PID()) {
-		node.log = append(node.log, &LogEntry{
-			Message: msg.Message,
-			Term:    node.currentTerm,
-		})
-		newLogIndex := uint64(len(node.log))
-		node.pendingCommands[newLogIndex] = &commandMetadata{
-			sender: act.Sender(),
+		node.checkQuorum(act)
+	}
+	if pidEquals(node.leader, act.PID()) {
+		return false
+	}
+	result.Error = "not the leader"
+	result.RedirectPID = node.leaderRedirect()
+	act.Send(act.Sender(), commandResult(offsetCommit, result))
+	return true
+}
+
+// leaderRedirect returns the leader to redirect a request this node cannot serve to, or nil if it is not known.
+func (node *RaftNode) leaderRedirect() *PID {
+	if node.leader == nil {
+		return nil
+	}
+	return ActorPIDToPID(node.leader)
+}
+
+// propose adds command to the batch, flushing it once it is full.
+func (node *RaftNode) propose(act NodeContext, command *batchedCommand) {
+	node.batch = append(node.batch, command)
+	switch {
+	case len(node.batch) >= node.config.MaxBatchSize || node.config.BatchInterval <= 0:
+		node.flushBatch(act)
+	case len(node.batch) == 1:
+		act.SetTimer(batchTimeout{}, node.config.BatchInterval)
+	}
+}
+
+// flushBatch appends the batched commands to the log with a single write
+// and replicates them with a single round of AppendEntries.
+func (node *RaftNode) flushBatch(act NodeContext) {
+	if len(node.batch) == 0 || !pidEquals(node.leader, act.PID()) {
+		return
+	}
+	batch := node.batch
+	node.batch = nil
+
+	now := act.Now()
+	entries := make([]*LogEntry, len(batch))
+	for i, command := range batch {
+		entries[i] = &LogEntry{
+			Message:      command.envelope.GetMessage(),
+			Term:         node.currentTerm,
+			Timestamp:    now.UnixNano(),
+			ProducerID:   command.envelope.GetProducerID(),
+			Sequence:     command.envelope.GetSequence(),
+			OffsetCommit: command.offsetCommit,
 		}
-		node.sendAppendEntriesAll(act)
-	} else {
-		var redirectPID *PID
-		if node.leader != nil {
-			redirectPID = ActorPIDToPID(node.leader)
+	}
+	err := setChecksums(entries...)
+	if err == nil {
+		err = node.store.Append(entries...)
+	}
+	if err != nil {
+		node.config.Logger.Error("flushBatch", "pid", act.PID(), "error", err)
+		for _, command := range batch {
+			act.Send(command.sender, commandResult(command.offsetCommit != nil, &EnvelopeResult{
+				Success: false,
+				Error:   err.Error(),
+			}))
+		}
+		return
+	}
+
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	firstIndex := lastLogIndex - uint64(len(entries)) + 1
+	for i, batched := range batch {
+		command := &commandMetadata{
+			sender:       batched.sender,
+			term:         node.currentTerm,
+			offsetCommit: batched.offsetCommit != nil,
+		}
+		if node.config.CommandTimeout > 0 {
+			command.deadline = now.Add(node.config.CommandTimeout)
 		}
-		act.Send(act.Sender(), &EnvelopeResult{
+		node.pendingCommands[firstIndex+uint64(i)] = command
+	}
+	node.config.Logger.Info("Appended batch", "pid", act.PID(), "index", firstIndex, "size", len(entries))
+	node.sendAppendEntriesAll(act, false)
+}
+
+func (node *RaftNode) handleTransferLeadership(act NodeContext, msg *TransferLeadership) {
+	node.config.Logger.Info("handleTransferLeadership", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	target := PIDToActorPID(msg.Target)
+	if !pidEquals(node.leader, act.PID()) {
+		act.Send(act.Sender(), &TransferLeadershipResult{
 			Success:     false,
-			RedirectPID: redirectPID,
+			Error:       "not the leader",
+			RedirectPID: node.leaderRedirect(),
+		})
+		return
+	}
+	if pidEquals(target, act.PID()) {
+		act.Send(act.Sender(), &TransferLeadershipResult{
+			Success: true,
 		})
+		return
+	}
+	if node.transfer != nil {
+		act.Send(act.Sender(), &TransferLeadershipResult{
+			Success: false,
+			Error:   "leadership transfer already in progress",
+		})
+		return
 	}
+	metadata, ok := node.nodes[target.LookupKey()]
+	if !ok || metadata.learner {
+		act.Send(act.Sender(), &TransferLeadershipResult{
+			Success: false,
+			Error:   "target is not a voting member of the cluster",
+		})
+		return
+	}
+
+	// Envelopes accepted before the transfer started are replicated to the target with the rest of the log
+	node.flushBatch(act)
+	node.transfer = &leadershipTransfer{
+		target:   target,
+		sender:   act.Sender(),
+		deadline: act.Now().Add(node.config.ElectionMaxInterval),
+	}
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	if metadata.matchIndex >= lastLogIndex {
+		act.Send(target, &TimeoutNow{
+			Term: node.currentTerm,
+		})
+	} else if err := node.sendAppendEntries(act, target, false); err != nil {
+		node.config.Logger.Error("handleTransferLeadership", "pid", act.PID(), "error", err)
+	}
+}
+
+// checkQuorum makes the leader step down if it has not heard from a majority of the voters
+// within an election timeout, as it may have been partitioned away and replaced.
+func (node *RaftNode) checkQuorum(act NodeContext) {
+	active := 0
+	if node.isVoter(act.PID()) {
+		active++
+	}
+	for _, metadata := range node.nodes {
+		if !metadata.learner && act.Now().Sub(metadata.lastResponse) < node.config.ElectionMaxInterval {
+			active++
+		}
+	}
+	if active < node.quorum() {
+		node.config.Logger.Warn("Lost contact with a quorum, stepping down", "pid", act.PID(), "term", node.currentTerm, "active", active)
+		node.leader = nil
+		node.resetElectionTimer(act)
+	}
+}
+
+// updateLeadershipTransfer reports the outcome of an in progress leadership transfer once it is known.
+func (node *RaftNode) updateLeadershipTransfer(act NodeContext) {
+	if node.transfer == nil {
+		return
+	}
+	result := &TransferLeadershipResult{}
+	switch {
+	case pidEquals(node.leader, node.transfer.target):
+		result.Success = true
+	case node.leader != nil && !pidEquals(node.leader, act.PID()):
+		result.Error = "leadership moved to " + node.leader.String()
+		result.RedirectPID = node.leaderRedirect()
+	case act.Now().After(node.transfer.deadline):
+		result.Error = "leadership transfer timed out"
+	default:
+		return
+	}
+	act.Send(node.transfer.sender, result)
+	node.config.Logger.Info("Leadership transfer finished", "pid", act.PID(), "target", node.transfer.target, "result", result)
+	node.transfer = nil
 }
 
-func (node *nodeActor) handleAppendEntries(act *actor.Context, msg *AppendEntries) {
+func (node *RaftNode) handleTimeoutNow(act NodeContext, msg *TimeoutNow) {
+	node.config.Logger.Info("handleTimeoutNow", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	if msg.Term == node.currentTerm && pidEquals(node.leader, act.Sender()) {
+		node.leader = nil
+		node.resetElectionTimer(act)
+		node.startElection(act)
+	}
+}
+
+func (node *RaftNode) handleAppendEntries(act NodeContext, msg *AppendEntries) {
 	result := &AppendEntriesResult{}
 	defer func() {
 		result.Term = node.currentTerm
-		act.Send(act.Sender(), result)
+		result.ReadSeq = msg.ReadSeq
+		if len(msg.Entries) > 0 {
+			result.LastIndex = msg.PrevLogIndex + uint64(len(msg.Entries))
+		}
+		if len(msg.Entries) == 0 {
+			node.sendRaft(act, act.Sender(), result)
+		} else {
+			act.Send(act.Sender(), result)
+		}
 		node.config.Logger.Info("handleAppendEntries", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "result", result)
 	}()
 
@@ -180,72 +501,356 @@ func (node *nodeActor) handleAppendEntries(act *actor.Context, msg *AppendEntrie
 	}
 
 	node.leader = act.Sender()
+	node.lastLeaderContact = act.Now()
+
+	// Entries covered by our snapshot are committed and therefore match the leader's log
+	prevLogIndex, prevLogTerm, entries := msg.PrevLogIndex, msg.PrevLogTerm, msg.Entries
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
+		result.Success = false
+		return
+	}
+	if prevLogIndex < snapshot.LastIncludedIndex {
+		entries = entries[min(snapshot.LastIncludedIndex-prevLogIndex, uint64(len(entries))):]
+		prevLogIndex, prevLogTerm = snapshot.LastIncludedIndex, snapshot.LastIncludedTerm
+	}
 
 	// Condition #2
 	// Reply false if log doesn't contain an entry at prevLogIndex whose term matches prevLogTerm
-	if msg.PrevLogIndex > 0 {
-		if len(node.log) < int(msg.PrevLogIndex) || (len(node.log) > 0 && node.log[msg.PrevLogIndex-1].Term != msg.PrevLogTerm) {
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	// The conflict hints let the leader skip past the whole mismatching term in one round-trip
+	if prevLogIndex > 0 {
+		if lastLogIndex < prevLogIndex {
+			result.Success = false
+			result.ConflictIndex = lastLogIndex + 1
+			return
+		}
+		term, err := node.store.Term(prevLogIndex)
+		if err != nil {
+			node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
+			result.Success = false
+			return
+		}
+		if term != prevLogTerm {
 			result.Success = false
+			result.ConflictTerm = term
+			result.ConflictIndex = node.firstIndexOfTerm(prevLogIndex, term)
 			return
 		}
 	}
 
-	newEntryIndex := msg.PrevLogIndex
-	for _, entry := range msg.Entries {
+	// Reject entries that were corrupted on the way so the leader sends them again
+	for i, entry := range entries {
+		if err := verifyChecksum(prevLogIndex+uint64(i)+1, entry); err != nil {
+			node.recordChecksumFailure(act, err)
+			result.Success = false
+			result.ConflictIndex = prevLogIndex + 1
+			return
+		}
+	}
+
+	newEntryIndex := prevLogIndex
+	configurationChanged := false
+	for i, entry := range entries {
 		newEntryIndex++
 
 		// Condition #3
 		// If an existing entry conflicts with a new one (same index but different terms),
 		// delete the existing entry and all that follow it
-		if len(node.log) >= int(newEntryIndex) && node.log[newEntryIndex-1].Term != entry.Term {
-			node.log = node.log[:newEntryIndex-1]
+		if lastLogIndex >= newEntryIndex {
+			term, err := node.store.Term(newEntryIndex)
+			if err != nil {
+				node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
+				result.Success = false
+				return
+			}
+			if term == entry.Term {
+				continue
+			}
+			if err := node.store.TruncateSuffix(newEntryIndex); err != nil {
+				node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
+				result.Success = false
+				return
+			}
+			node.failPendingCommands(act, newEntryIndex, "entry was overwritten by a new leader")
+			configurationChanged = configurationChanged || newEntryIndex <= node.configurationIndex
 		}
 
 		// Condition #4
 		// Append any new entries not already in the log
-		if len(node.log) < int(newEntryIndex) {
-			node.log = append(node.log, entry)
+		if err := node.store.Append(entries[i:]...); err != nil {
+			node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
+			result.Success = false
+			return
+		}
+		for _, entry := range entries[i:] {
+			configurationChanged = configurationChanged || entry.Configuration != nil
+		}
+		newEntryIndex = prevLogIndex + uint64(len(entries))
+		break
+	}
+
+	if configurationChanged {
+		if err := node.reloadConfiguration(act); err != nil {
+			node.config.Logger.Error("handleAppendEntries", "pid", act.PID(), "error", err)
 		}
 	}
 
 	// Condition #5
 	// If leaderCommit > commitIndex,
 	// set commitIndex = min(leaderCommit, index of last new entry)
+	// Pipelined batches can arrive out of order, so an older batch must not move it back
 	if msg.LeaderCommit > node.commitIndex {
-		node.commitIndex = min(msg.LeaderCommit, newEntryIndex)
+		node.commitIndex = max(node.commitIndex, min(msg.LeaderCommit, newEntryIndex))
 	}
 
 	result.Success = true
+	result.MatchIndex = newEntryIndex
+	if node.repairing && newEntryIndex >= msg.LeaderCommit {
+		node.repairing = false
+		node.config.Logger.Info("Log repaired", "pid", act.PID(), "sender", act.Sender(), "index", newEntryIndex)
+	}
+
+	node.resetElectionTimer(act)
+}
+
+// recordChecksumFailure logs a corrupted entry and keeps track of it for NodeStatus.
+func (node *RaftNode) recordChecksumFailure(act NodeContext, err error) {
+	node.checksumFailures++
+	node.lastChecksumError = err.Error()
+	node.config.Logger.Error("Checksum mismatch", "pid", act.PID(), "sender", act.Sender(), "failures", node.checksumFailures, "error", err)
+}
+
+// awaitingRepair reports whether the node dropped corrupted entries when it restarted and no leader has sent them again yet.
+// The dropped entries may have been committed, so the node neither votes nor starts elections unless it is the only voter.
+func (node *RaftNode) awaitingRepair() bool {
+	return node.repairing && node.quorum() > 1
+}
+
+// canVote reports whether the node may grant votes and pre-votes: it is a voter rather than a learner,
+// and is not awaiting repair.
+func (node *RaftNode) canVote(act NodeContext) bool {
+	return node.isVoter(act.PID()) && !node.awaitingRepair()
+}
 
-	node.electionTimer.Reset(newElectionTimoutDuration(node.config))
+func (node *RaftNode) handleNodeStatus(act NodeContext, msg *NodeStatus) {
+	act.Send(act.Sender(), node.Status())
 }
 
-func (node *nodeActor) handleAppendEntriesResult(act *actor.Context, msg *AppendEntriesResult) {
+// Status returns a summary of the node's Raft state.
+func (node *RaftNode) Status() *NodeStatusResult {
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	return &NodeStatusResult{
+		Term:              node.currentTerm,
+		Leader:            ActorPIDToPID(node.leader),
+		CommitIndex:       node.commitIndex,
+		LastApplied:       node.lastApplied,
+		LastLogIndex:      lastLogIndex,
+		Learner:           !node.isVoter(node.pid),
+		ChecksumFailures:  node.checksumFailures,
+		LastChecksumError: node.lastChecksumError,
+	}
+}
+
+func (node *RaftNode) handleAppendEntriesResult(act NodeContext, msg *AppendEntriesResult) {
 	node.config.Logger.Info("handleAppendEntriesResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
 	metadata, ok := node.nodes[act.Sender().LookupKey()]
 	if !ok {
 		node.config.Logger.Error("handleAppendEntriesResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "error", errors.New("could not find PID"))
 		return
 	}
+	if !pidEquals(node.leader, act.PID()) || msg.Term != node.currentTerm {
+		return
+	}
+	metadata.lastResponse = act.Now()
+	metadata.readSeq = max(metadata.readSeq, msg.ReadSeq)
+	if sent, ok := node.heartbeatSent(msg.ReadSeq); ok && sent.After(metadata.leaseStart) {
+		metadata.leaseStart = sent
+	}
+	// Heartbeats never take a place in the window, so only responses to batches of entries free one
+	batch := msg.LastIndex > 0
+	if batch {
+		metadata.lastAppend = act.Now()
+	}
 	if msg.Success {
 		lastLogIndex, _ := node.lastLogIndexAndTerm()
-		metadata.matchIndex = metadata.nextIndex
-		metadata.nextIndex = lastLogIndex + 1
+		metadata.matchIndex = max(metadata.matchIndex, msg.MatchIndex)
+		metadata.nextIndex = max(metadata.nextIndex, msg.MatchIndex+1)
+		// Every batch sent before this one has been acknowledged once the follower has caught up with nextIndex
+		if batch && msg.MatchIndex+1 >= metadata.nextIndex {
+			metadata.inflight = 0
+		} else if batch && metadata.inflight > 0 {
+			metadata.inflight--
+		}
+		if node.transfer != nil && pidEquals(node.transfer.target, metadata.pid) && metadata.matchIndex >= lastLogIndex {
+			act.Send(metadata.pid, &TimeoutNow{
+				Term: node.currentTerm,
+			})
+		}
 	} else {
-		if metadata.nextIndex > 1 {
-			metadata.nextIndex--
+		metadata.nextIndex = node.nextIndexFromConflict(metadata.nextIndex, msg.ConflictTerm, msg.ConflictIndex)
+		if batch {
+			metadata.inflight = 0
 		}
-		if err := node.sendAppendEntries(act, metadata.pid); err != nil {
-			node.config.Logger.Error("handleAppendEntriesResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "error", err)
+	}
+	if err := node.sendAppendEntries(act, metadata.pid, false); err != nil {
+		node.config.Logger.Error("handleAppendEntriesResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "error", err)
+	}
+}
+
+// firstIndexOfTerm returns the first index of the run of entries with term that ends at index.
+// The search stops at the start of the log, as compacted entries cannot be inspected.
+func (node *RaftNode) firstIndexOfTerm(index uint64, term uint64) uint64 {
+	for index > 1 {
+		prevTerm, err := node.store.Term(index - 1)
+		if err != nil || prevTerm != term {
+			break
 		}
+		index--
 	}
+	return index
 }
 
-func (node *nodeActor) handleRequestVote(act *actor.Context, msg *RequestVote) {
-	result := &RequestVoteResult{}
+// nextIndexFromConflict picks the next index to send a follower from the conflict hints in its rejection.
+// If the leader has entries from the conflicting term, the follower is sent everything after the last of them,
+// otherwise the whole conflicting term is skipped.
+func (node *RaftNode) nextIndexFromConflict(nextIndex uint64, conflictTerm uint64, conflictIndex uint64) uint64 {
+	if conflictIndex == 0 {
+		if nextIndex > 1 {
+			return nextIndex - 1
+		}
+		return nextIndex
+	}
+	lastLogIndex, _ := node.lastLogIndexAndTerm()
+	if conflictTerm > 0 {
+		for index := lastLogIndex; index > 0; index-- {
+			term, err := node.store.Term(index)
+			if err != nil || term < conflictTerm {
+				break
+			}
+			if term == conflictTerm {
+				return index + 1
+			}
+		}
+	}
+	return min(max(conflictIndex, 1), lastLogIndex+1)
+}
+
+func (node *RaftNode) handleInstallSnapshot(act NodeContext, msg *InstallSnapshot) {
+	result := &InstallSnapshotResult{}
 	defer func() {
 		result.Term = node.currentTerm
 		act.Send(act.Sender(), result)
+		node.config.Logger.Info("handleInstallSnapshot", "pid", act.PID(), "sender", act.Sender(), "index", msg.Snapshot.GetLastIncludedIndex(), "result", result)
+	}()
+
+	// Reply immediately if term < currentTerm
+	if msg.Term < node.currentTerm {
+		return
+	}
+
+	node.leader = act.Sender()
+	node.lastLeaderContact = act.Now()
+	node.resetElectionTimer(act)
+
+	// Ignore snapshots that do not cover anything beyond what is already committed
+	snapshot := msg.Snapshot
+	if snapshot.LastIncludedIndex <= node.commitIndex {
+		result.LastIncludedIndex = node.commitIndex
+		return
+	}
+
+	if err := node.store.SaveSnapshot(snapshot); err != nil {
+		node.config.Logger.Error("handleInstallSnapshot", "pid", act.PID(), "error", err)
+		return
+	}
+	if err := node.config.StateMachine.Restore(snapshot.Data); err != nil {
+		node.config.Logger.Error("handleInstallSnapshot", "pid", act.PID(), "error", err)
+		return
+	}
+	node.restoreProducers(snapshot)
+	node.commitIndex = snapshot.LastIncludedIndex
+	node.lastApplied = snapshot.LastIncludedIndex
+	node.pendingSnapshot = nil
+	result.LastIncludedIndex = snapshot.LastIncludedIndex
+	if err := node.reloadConfiguration(act); err != nil {
+		node.config.Logger.Error("handleInstallSnapshot", "pid", act.PID(), "error", err)
+	}
+}
+
+func (node *RaftNode) handleInstallSnapshotResult(act NodeContext, msg *InstallSnapshotResult) {
+	node.config.Logger.Info("handleInstallSnapshotResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	metadata, ok := node.nodes[act.Sender().LookupKey()]
+	if !ok {
+		node.config.Logger.Error("handleInstallSnapshotResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "error", errors.New("could not find PID"))
+		return
+	}
+	if !pidEquals(node.leader, act.PID()) || msg.Term != node.currentTerm {
+		return
+	}
+	metadata.lastResponse = act.Now()
+	metadata.matchIndex = max(metadata.matchIndex, msg.LastIncludedIndex)
+	metadata.nextIndex = max(metadata.nextIndex, msg.LastIncludedIndex+1)
+	metadata.inflight = 0
+	if err := node.sendAppendEntries(act, metadata.pid, false); err != nil {
+		node.config.Logger.Error("handleInstallSnapshotResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "error", err)
+	}
+}
+
+func (node *RaftNode) handlePreVote(act NodeContext, msg *PreVote) {
+	result := &PreVoteResult{}
+	defer func() {
+		if result.VoteGranted {
+			result.Term = msg.Term
+		} else {
+			result.Term = node.currentTerm
+		}
+		node.sendRaft(act, act.Sender(), result)
+		node.config.Logger.Info("handlePreVote", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "result", result)
+	}()
+
+	// Reject candidates that could not win an election for the term
+	if msg.Term <= node.currentTerm {
+		return
+	}
+
+	if !node.canVote(act) {
+		return
+	}
+
+	// Reject candidates while we still believe in a live leader
+	if pidEquals(node.leader, act.PID()) || (node.leader != nil && act.Now().Sub(node.lastLeaderContact) < node.config.ElectionMinInterval) {
+		return
+	}
+
+	// Grant if the candidate's log is at least as up-to-date as ours
+	if node.logUpToDate(msg.LastLogIndex, msg.LastLogTerm) {
+		result.VoteGranted = true
+	}
+}
+
+func (node *RaftNode) handlePreVoteResult(act NodeContext, msg *PreVoteResult) {
+	node.config.Logger.Info("handlePreVoteResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	if !msg.VoteGranted {
+		node.handleExternalTerm(act, msg.Term)
+		return
+	}
+	// Grants are counted once per voter, and only for the current pre-vote round
+	metadata, ok := node.nodes[act.Sender().LookupKey()]
+	if msg.Term == node.currentTerm+1 && node.leader == nil && ok && !metadata.learner {
+		node.preVotes[act.Sender().LookupKey()] = true
+		if len(node.preVotes)+1 >= node.quorum() {
+			node.startElection(act)
+		}
+	}
+}
+
+func (node *RaftNode) handleRequestVote(act NodeContext, msg *RequestVote) {
+	result := &RequestVoteResult{}
+	defer func() {
+		result.Term = node.currentTerm
+		node.sendRaft(act, act.Sender(), result)
 		node.config.Logger.Info("handleRequestVote", "pid", act.PID(), "sender", act.Sender(), "msg", msg, "result", result)
 	}()
 
@@ -256,44 +861,77 @@ func (node *nodeActor) handleRequestVote(act *actor.Context, msg *RequestVote) {
 		return
 	}
 
+	if !node.canVote(act) {
+		result.VoteGranted = false
+		return
+	}
+
 	// Condition #2
 	// If votedFor is null or candidateId,
 	// and candidate's log is at least as up-to-date as receiver's log, grant vote
 	candidatePID := act.Sender()
 	if node.votedFor == nil || node.votedFor.String() == candidatePID.String() {
-		if msg.LastLogIndex >= node.lastApplied && (node.lastApplied == 0 || msg.LastLogTerm >= node.log[node.lastApplied-1].Term) {
+		if node.logUpToDate(msg.LastLogIndex, msg.LastLogTerm) {
 			node.votedFor = candidatePID
+			if err := node.persistHardState(); err != nil {
+				node.config.Logger.Error("handleRequestVote", "pid", act.PID(), "error", err)
+				node.votedFor = nil
+				return
+			}
 			result.VoteGranted = true
 		}
 	}
 }
 
-func (node *nodeActor) handleRequestVoteResult(act *actor.Context, msg *RequestVoteResult) {
+// logUpToDate reports whether a log ending at lastLogIndex and lastLogTerm is at least as up-to-date as ours.
+// The log with the later last term is more up-to-date, and logs ending with the same term are compared by length.
+// Comparing against the last applied entry instead would let a candidate missing committed entries win the election.
+func (node *RaftNode) logUpToDate(lastLogIndex uint64, lastLogTerm uint64) bool {
+	ourLastLogIndex, ourLastLogTerm := node.lastLogIndexAndTerm()
+	return lastLogTerm > ourLastLogTerm || (lastLogTerm == ourLastLogTerm && lastLogIndex >= ourLastLogIndex)
+}
+
+func (node *RaftNode) handleRequestVoteResult(act NodeContext, msg *RequestVoteResult) {
 	node.config.Logger.Info("handleRequestVoteResult", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
 	if msg.VoteGranted && msg.Term == node.currentTerm && !pidEquals(node.leader, act.PID()) {
 		node.votes++
-		if float32(node.votes)/float32(len(node.nodes)) > 0.5 {
+		if int(node.votes) >= node.quorum() {
 			node.config.Logger.Info("Promoted to leader", "pid", act.PID(), "sender", act.Sender())
 			node.leader = act.PID()
 			lastLogIndex, _ := node.lastLogIndexAndTerm()
 			for _, metadata := range node.nodes {
 				metadata.nextIndex = lastLogIndex + 1
 				metadata.matchIndex = 0
+				metadata.inflight = 0
+				metadata.lastResponse = act.Now()
+				metadata.leaseStart = time.Time{}
 			}
-			node.sendAppendEntriesAll(act)
+			node.heartbeats = nil
+			// Restating the configuration commits an entry from the new term before any configuration change,
+			// and writes the bootstrap configuration to the log
+			node.proposeConfiguration(act, node.configuration)
 		}
 	}
 }
 
-func (node *nodeActor) sendAppendEntriesAll(act *actor.Context) {
+// sendAppendEntriesAll replicates new entries to every follower.
+// With heartbeat set, followers that were sent nothing receive an empty AppendEntries instead,
+// and the messages start a new heartbeat round.
+func (node *RaftNode) sendAppendEntriesAll(act NodeContext, heartbeat bool) {
+	if heartbeat {
+		node.startHeartbeatRound(act)
+	}
 	for _, metadata := range node.nodes {
-		if err := node.sendAppendEntries(act, metadata.pid); err != nil {
+		if err := node.sendAppendEntries(act, metadata.pid, heartbeat); err != nil {
 			node.config.Logger.Error("Sending AppendEntries for "+metadata.pid.String(), "pid", act.PID(), "error", err.Error())
 		}
 	}
 }
 
-func (node *nodeActor) sendAppendEntries(act *actor.Context, pid *actor.PID) error {
+// sendAppendEntries sends a follower batches of entries from nextIndex until MaxInflightAppends
+// batches are awaiting a response, advancing nextIndex optimistically as each batch is sent.
+// A rejection resets nextIndex from the conflict hints and empties the window again.
+func (node *RaftNode) sendAppendEntries(act NodeContext, pid *actor.PID, heartbeat bool) error {
 	metadata, ok := node.nodes[pid.LookupKey()]
 	if !ok {
 		return errors.New("server does not exist")
@@ -303,44 +941,152 @@ func (node *nodeActor) sendAppendEntries(act *actor.Context, pid *actor.PID) err
 		return errors.New("nextIndex is 0 for " + pid.String())
 	}
 
-	entries := []*LogEntry{}
+	// Assume the in-flight batches were lost if none has been answered for an election timeout
+	if heartbeat && metadata.inflight > 0 && act.Now().Sub(metadata.lastAppend) > node.config.ElectionMaxInterval {
+		metadata.inflight = 0
+	}
+	maxInflight := max(node.config.MaxInflightAppends, 1)
+
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// The entries the follower needs have been compacted, so send the snapshot instead
+	if metadata.nextIndex <= snapshot.LastIncludedIndex {
+		if metadata.inflight == 0 {
+			act.Send(metadata.pid, &InstallSnapshot{
+				Term:     node.currentTerm,
+				Snapshot: snapshot,
+			})
+			metadata.inflight = maxInflight
+			metadata.lastAppend = act.Now()
+		}
+		return nil
+	}
+
 	lastLogIndex, _ := node.lastLogIndexAndTerm()
-	if lastLogIndex >= metadata.nextIndex {
-		entries = node.log[metadata.nextIndex-1:]
+	sent := false
+	for metadata.inflight < maxInflight && metadata.nextIndex <= lastLogIndex {
+		entries, err := node.appendEntriesBatch(metadata.nextIndex, lastLogIndex)
+		if err != nil {
+			return err
+		}
+		if err := node.sendAppendEntriesBatch(act, metadata, entries); err != nil {
+			return err
+		}
+		if metadata.inflight == 0 {
+			metadata.lastAppend = act.Now()
+		}
+		metadata.nextIndex += uint64(len(entries))
+		metadata.inflight++
+		sent = true
+	}
+
+	if heartbeat && !sent {
+		return node.sendAppendEntriesBatch(act, metadata, nil)
+	}
+	return nil
+}
+
+// appendEntriesBatch returns the entries from nextIndex onwards that fit within
+// MaxAppendEntries and MaxAppendBytes, always including at least one entry.
+func (node *RaftNode) appendEntriesBatch(nextIndex uint64, lastLogIndex uint64) ([]*LogEntry, error) {
+	hi := lastLogIndex + 1
+	if node.config.MaxAppendEntries > 0 {
+		hi = min(hi, nextIndex+node.config.MaxAppendEntries)
+	}
+	entries, err := node.store.Entries(nextIndex, hi)
+	if err != nil {
+		return nil, err
+	}
+	if node.config.MaxAppendBytes > 0 {
+		size := 0
+		for i, entry := range entries {
+			size += proto.Size(entry)
+			if i > 0 && size > node.config.MaxAppendBytes {
+				return entries[:i], nil
+			}
+		}
 	}
+	return entries, nil
+}
 
+func (node *RaftNode) sendAppendEntriesBatch(act NodeContext, metadata *nodeMetadata, entries []*LogEntry) error {
 	var prevLogIndex uint64 = metadata.nextIndex - 1
-	var prevLogTerm uint64 = 0
-	if prevLogIndex > 0 {
-		prevLogTerm = node.log[prevLogIndex-1].Term
+	prevLogTerm, err := node.store.Term(prevLogIndex)
+	if err != nil {
+		return err
 	}
 
-	act.Send(metadata.pid, &AppendEntries{
+	msg := &AppendEntries{
 		Term:         node.currentTerm,
 		PrevLogTerm:  prevLogTerm,
 		PrevLogIndex: prevLogIndex,
 		Entries:      entries,
 		LeaderCommit: node.commitIndex,
-	})
+		ReadSeq:      node.readSeq,
+	}
+	if len(entries) == 0 {
+		node.sendRaft(act, metadata.pid, msg)
+	} else {
+		act.Send(metadata.pid, msg)
+	}
 	return nil
 }
 
-func (node *nodeActor) startElection(act *actor.Context) {
+// startPreVote asks the other servers whether they would vote for us in the next term,
+// so that the term is only incremented by an election we could win.
+func (node *RaftNode) startPreVote(act NodeContext) {
+	node.leader = nil
+	node.preVotes = make(map[uint64]bool)
+
+	if !node.isVoter(act.PID()) {
+		return
+	}
+
+	if len(node.configuration.GetVoters()) < int(node.config.ElectionMinServers) {
+		node.config.Logger.Warn("Not enough servers for election", "pid", act.PID())
+		return
+	}
+
+	node.config.Logger.Info("Starting pre-vote", "pid", act.PID(), "term", node.currentTerm+1)
+	lastLogIndex, lastLogTerm := node.lastLogIndexAndTerm()
+	for _, metadata := range node.nodes {
+		if metadata.learner {
+			continue
+		}
+		node.sendRaft(act, metadata.pid, &PreVote{
+			Term:         node.currentTerm + 1,
+			LastLogIndex: lastLogIndex,
+			LastLogTerm:  lastLogTerm,
+		})
+	}
+}
+
+func (node *RaftNode) startElection(act NodeContext) {
 	defer func() {
 		node.config.Logger.Info("Starting election", "pid", act.PID(), "term", node.currentTerm)
 	}()
 	node.currentTerm++
 	node.votes = 1
 	node.votedFor = act.PID()
+	if err := node.persistHardState(); err != nil {
+		node.config.Logger.Error("Starting election", "pid", act.PID(), "error", err)
+		return
+	}
 
-	if len(node.nodes)+1 < int(node.config.ElectionMinServers) {
+	if len(node.configuration.GetVoters()) < int(node.config.ElectionMinServers) {
 		node.config.Logger.Warn("Not enough servers for election", "pid", act.PID())
 		return
 	}
 
 	lastLogIndex, lastLogTerm := node.lastLogIndexAndTerm()
 	for _, metadata := range node.nodes {
-		act.Send(metadata.pid, &RequestVote{
+		if metadata.learner {
+			continue
+		}
+		node.sendRaft(act, metadata.pid, &RequestVote{
 			Term:         node.currentTerm,
 			LastLogIndex: lastLogIndex,
 			LastLogTerm:  lastLogTerm,
@@ -348,57 +1094,276 @@ func (node *nodeActor) startElection(act *actor.Context) {
 	}
 }
 
-func (node *nodeActor) lastLogIndexAndTerm() (uint64, uint64) {
-	var lastLogIndex uint64 = uint64(len(node.log))
-	var lastLogTerm uint64 = 0
-	if lastLogIndex > 0 {
-		lastLogTerm = node.log[lastLogIndex-1].Term
-	}
-	return lastLogIndex, lastLogTerm
+func (node *RaftNode) resetElectionTimer(act NodeContext) {
+	act.SetTimer(electionTimeout{}, newElectionTimoutDuration(node.config, act.Rand()))
 }
 
-func (node *nodeActor) handleExternalTerm(term uint64) {
+func (node *RaftNode) lastLogIndexAndTerm() (uint64, uint64) {
+	return node.store.LastIndexAndTerm()
+}
+
+func (node *RaftNode) handleExternalTerm(act NodeContext, term uint64) {
 	if term > node.currentTerm {
 		node.currentTerm = term
 		node.leader = nil
 		node.votedFor = nil
+		if err := node.persistHardState(); err != nil {
+			node.config.Logger.Error("handleExternalTerm", "pid", act.PID(), "term", term, "error", err)
+		}
+	}
+}
+
+// restore opens the log store, loads the hard state from it and restores the state machine from the latest snapshot.
+func (node *RaftNode) restore() error {
+	if node.config.LogStore == nil {
+		node.config.LogStore = NewMemoryLogStore()
 	}
+	store, err := node.config.LogStore()
+	if err != nil {
+		return err
+	}
+	node.store = store
+	state, err := node.store.HardState()
+	if err != nil {
+		return err
+	}
+	node.currentTerm = state.Term
+	node.votedFor = PIDToActorPID(state.VotedFor)
+
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		return err
+	}
+	if err := node.config.StateMachine.Restore(snapshot.Data); err != nil {
+		return err
+	}
+	node.restoreProducers(snapshot)
+	node.commitIndex = snapshot.LastIncludedIndex
+	node.lastApplied = snapshot.LastIncludedIndex
+	return nil
 }
 
-func (node *nodeActor) updateStateMachine(act *actor.Context) {
+func (node *RaftNode) persistHardState() error {
+	return node.store.SetHardState(&HardState{
+		Term:     node.currentTerm,
+		VotedFor: ActorPIDToPID(node.votedFor),
+	})
+}
+
+func (node *RaftNode) updateStateMachine(act NodeContext) {
 	if pidEquals(node.leader, act.PID()) {
-		for i := uint64(len(node.log)); i >= node.commitIndex+1; i-- {
-			if node.log[i-1].Term == node.currentTerm {
+		lastLogIndex, _ := node.lastLogIndexAndTerm()
+		for i := lastLogIndex; i >= node.commitIndex+1; i-- {
+			term, err := node.store.Term(i)
+			if err != nil {
+				node.config.Logger.Error("updateStateMachine", "pid", act.PID(), "index", i, "error", err)
+				break
+			}
+			if term == node.currentTerm {
 				matched := 0
+				if node.isVoter(act.PID()) {
+					matched++
+				}
 				for _, metadata := range node.nodes {
-					if metadata.matchIndex >= i {
+					if !metadata.learner && metadata.matchIndex >= i {
 						matched++
 					}
 				}
-				if float32(matched) > float32(len(node.nodes))/2 {
+				if matched >= node.quorum() {
 					node.commitIndex = i
 					break
 				}
 			}
 		}
 	}
+	lastApplied := node.lastApplied
 	for node.commitIndex > node.lastApplied {
+		entries, err := node.store.Entries(node.lastApplied+1, node.lastApplied+2)
+		if err != nil {
+			node.config.Logger.Error("updateStateMachine", "pid", act.PID(), "index", node.lastApplied+1, "error", err)
+			return
+		}
+		entry := entries[0]
+		duplicate := node.isDuplicate(entry)
+		if (entry.Message != nil || entry.OffsetCommit != nil) && !duplicate {
+			if err := node.config.StateMachine.Apply(node.lastApplied+1, entry); err != nil {
+				node.config.Logger.Error("updateStateMachine", "pid", act.PID(), "index", node.lastApplied+1, "error", err)
+				return
+			}
+		}
+		if duplicate {
+			node.duplicates[node.lastApplied+1] = true
+		} else {
+			node.recordProducer(node.lastApplied+1, entry)
+		}
 		node.lastApplied++
-		entry := node.log[node.lastApplied-1]
-		node.applyMessage(act, entry.GetMessage())
 		command, ok := node.pendingCommands[node.lastApplied]
 		if ok {
-			act.Send(command.sender, &EnvelopeResult{
-				Success: true,
-			})
+			// Another leader may have replaced the entry at this index with its own
+			result := &EnvelopeResult{
+				Success: command.term == entry.Term,
+			}
+			if result.Success && duplicate {
+				// A retry of an envelope that was already applied reports the original entry
+				producer := node.producers[entry.ProducerID]
+				if producer.Sequence == entry.Sequence {
+					result.Index = producer.Index
+					result.Term = producer.Term
+				}
+			} else if result.Success {
+				result.Index = node.lastApplied
+				result.Term = entry.Term
+			} else {
+				result.Error = "entry was overwritten by a new leader"
+			}
+			act.Send(command.sender, commandResult(command.offsetCommit, result))
 			delete(node.pendingCommands, node.lastApplied)
 		}
 		node.config.Logger.Info("Applied message", "pid", act.PID(), "index", node.lastApplied, "msg", entry.Message)
 	}
+	if node.lastApplied > lastApplied || node.pendingSnapshot != nil {
+		node.compactLog(act)
+	}
+
+	// A leader that removed itself from the configuration steps down once the removal has committed
+	if pidEquals(node.leader, act.PID()) && node.configurationIndex <= node.commitIndex && !node.isVoter(act.PID()) {
+		node.config.Logger.Info("Removed from configuration", "pid", act.PID())
+		node.leader = nil
+	}
 }
 
-func (node *nodeActor) applyMessage(act *actor.Context, msg *Message) {
-	act.Send(act.Parent(), &ConsumerEnvelope{
-		Message: msg,
-	})
+// updatePendingCommands fails the commands whose outcome can no longer be reported by this node.
+// Commands are failed once the node is no longer the leader or their deadline has passed,
+// although their entries may still be committed later.
+// Commands still waiting in the batch are failed along with them when the node is no longer the leader.
+func (node *RaftNode) updatePendingCommands(act NodeContext) {
+	if len(node.pendingCommands) == 0 && len(node.batch) == 0 {
+		return
+	}
+	if !pidEquals(node.leader, act.PID()) {
+		redirectPID := node.leaderRedirect()
+		for _, command := range node.batch {
+			act.Send(command.sender, commandResult(command.offsetCommit != nil, &EnvelopeResult{
+				Success:     false,
+				Error:       "not the leader",
+				RedirectPID: redirectPID,
+			}))
+		}
+		node.batch = nil
+		for _, index := range node.pendingIndexes(0) {
+			command := node.pendingCommands[index]
+			act.Send(command.sender, commandResult(command.offsetCommit, &EnvelopeResult{
+				Success:     false,
+				Error:       "not the leader",
+				RedirectPID: redirectPID,
+			}))
+			delete(node.pendingCommands, index)
+		}
+		return
+	}
+	now := act.Now()
+	for _, index := range node.pendingIndexes(0) {
+		command := node.pendingCommands[index]
+		if !command.deadline.IsZero() && now.After(command.deadline) {
+			act.Send(command.sender, commandResult(command.offsetCommit, &EnvelopeResult{
+				Success: false,
+				Error:   "command timed out",
+			}))
+			delete(node.pendingCommands, index)
+		}
+	}
+}
+
+// failPendingCommands fails every pending command at or after index.
+func (node *RaftNode) failPendingCommands(act NodeContext, index uint64, reason string) {
+	for _, i := range node.pendingIndexes(index) {
+		command := node.pendingCommands[i]
+		act.Send(command.sender, commandResult(command.offsetCommit, &EnvelopeResult{
+			Success: false,
+			Error:   reason,
+		}))
+		delete(node.pendingCommands, i)
+	}
+}
+
+// pendingIndexes returns the indexes of the pending commands at or after index in ascending order,
+// so their results are sent in the order the commands were proposed rather than in map order.
+func (node *RaftNode) pendingIndexes(index uint64) []uint64 {
+	indexes := make([]uint64, 0, len(node.pendingCommands))
+	for i := range node.pendingCommands {
+		if i >= index {
+			indexes = append(indexes, i)
+		}
+	}
+	slices.Sort(indexes)
+	return indexes
+}
+
+// commandResult returns result as the message the sender of a command expects.
+func commandResult(offsetCommit bool, result *EnvelopeResult) any {
+	if offsetCommit {
+		return &CommitOffsetResult{
+			Success:     result.Success,
+			Error:       result.Error,
+			RedirectPID: result.RedirectPID,
+		}
+	}
+	return result
+}
+
+// compactLog replaces the applied prefix of the log with a snapshot
+// once SnapshotThreshold entries have been applied since the last one.
+// The snapshot is taken straight away, but a LogRetainer state machine can hold it back
+// until the entries it covers are no longer read from the log, or until MaxRetainedEntries
+// have been applied after it. Readers left behind then find their entries compacted.
+func (node *RaftNode) compactLog(act NodeContext) {
+	if node.config.SnapshotThreshold == 0 {
+		return
+	}
+	if node.pendingSnapshot == nil {
+		snapshot, err := node.store.Snapshot()
+		if err != nil {
+			node.config.Logger.Error("compactLog", "pid", act.PID(), "error", err)
+			return
+		}
+		if node.lastApplied-snapshot.LastIncludedIndex < node.config.SnapshotThreshold {
+			return
+		}
+		term, err := node.store.Term(node.lastApplied)
+		if err != nil {
+			node.config.Logger.Error("compactLog", "pid", act.PID(), "error", err)
+			return
+		}
+		configuration, _, err := node.configurationAt(node.lastApplied)
+		if err != nil {
+			node.config.Logger.Error("compactLog", "pid", act.PID(), "error", err)
+			return
+		}
+		data, err := node.config.StateMachine.Snapshot()
+		if err != nil {
+			node.config.Logger.Error("compactLog", "pid", act.PID(), "error", err)
+			return
+		}
+		node.pendingSnapshot = &Snapshot{
+			LastIncludedIndex: node.lastApplied,
+			LastIncludedTerm:  term,
+			Data:              data,
+			Configuration:     configuration,
+			Producers:         node.producerStates(),
+		}
+	}
+	snapshot := node.pendingSnapshot
+	if retainer, ok := node.config.StateMachine.(LogRetainer); ok && retainer.RetainFrom() <= snapshot.LastIncludedIndex {
+		if node.config.MaxRetainedEntries == 0 || node.lastApplied-snapshot.LastIncludedIndex < node.config.MaxRetainedEntries {
+			return
+		}
+		node.config.Logger.Warn("Compacting retained entries", "pid", act.PID(), "retainFrom", retainer.RetainFrom(), "index", snapshot.LastIncludedIndex)
+	}
+	node.pendingSnapshot = nil
+	if err := node.store.SaveSnapshot(snapshot); err != nil {
+		node.config.Logger.Error("compactLog", "pid", act.PID(), "error", err)
+		return
+	}
+	node.forgetDuplicates(snapshot.LastIncludedIndex)
+	node.config.Logger.Info("Compacted log", "pid", act.PID(), "index", snapshot.LastIncludedIndex, "term", snapshot.LastIncludedTerm)
 }
@@ -1,20 +1,36 @@
 package cluster
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/anthdm/hollywood/actor"
 )
 
 type PodConfig struct {
+	// ID is the stable name the pod is spawned with using actor.WithID.
+	// A pod's nodes are named after it, so a pod restarted with the same ID, address and DataDir
+	// rejoins its Raft groups as the same server. A pod with a DataDir must have one.
+	ID        string
 	Topics    []string
 	Discovery *actor.PID
 	Logger    *slog.Logger
+	DataDir   string
+	Learner   bool
+	// HeartbeatInterval is the heartbeat interval of every topic's node and how often heartbeats
+	// between pods are sent. The node default is used if it is zero.
+	HeartbeatInterval time.Duration
 }
 
+type raftBatchTimeout struct{}
+
 type podActor struct {
-	config PodConfig
-	topics map[string]*actor.PID
+	config        PodConfig
+	topics        map[string]*actor.PID
+	batcher       *raftBatcher
+	batchRepeater actor.SendRepeater
 }
 
 func NewPod(config PodConfig) actor.Producer {
@@ -29,17 +45,39 @@ func (pod *podActor) Receive(act *actor.Context) {
 	switch msg := act.Message().(type) {
 	case actor.Initialized:
 		pod.topics = make(map[string]*actor.PID)
+		pod.batcher = newRaftBatcher()
+		if pod.config.HeartbeatInterval == 0 {
+			pod.config.HeartbeatInterval = NewNodeConfig().HeartbeatInterval
+		}
 
 	case actor.Started:
+		if pod.config.DataDir != "" && (pod.config.ID == "" || !strings.HasSuffix(act.PID().GetID(), "/"+pod.config.ID)) {
+			panic(fmt.Sprintf("pod %s with a DataDir must be spawned with its ID %q", act.PID(), pod.config.ID))
+		}
 		for _, topic := range pod.config.Topics {
 			pod.topics[topic] = act.SpawnChild(NewTopic(TopicConfig{
-				Topic:     topic,
-				Discovery: pod.config.Discovery,
-				Logger:    pod.config.Logger,
+				Topic:             topic,
+				Discovery:         pod.config.Discovery,
+				Logger:            pod.config.Logger,
+				DataDir:           pod.config.DataDir,
+				Learner:           pod.config.Learner,
+				Transport:         act.PID(),
+				HeartbeatInterval: pod.config.HeartbeatInterval,
 			}), "topic", actor.WithID(topic))
 		}
+		pod.batchRepeater = act.SendRepeat(act.PID(), raftBatchTimeout{}, pod.config.HeartbeatInterval)
 
 	case actor.Stopped:
+		pod.batchRepeater.Stop()
+
+	case raftBatchTimeout:
+		pod.batcher.flush(act)
+
+	case *RaftEnvelope:
+		pod.batcher.add(act, msg)
+
+	case *RaftBatch:
+		deliverRaftBatch(act, msg)
 
 	case *Envelope:
 		topic, ok := pod.topics[msg.Topic]
@@ -52,6 +90,81 @@ func (pod *podActor) Receive(act *actor.Context) {
 		}
 		act.Engine().SendWithSender(topic, msg, act.Sender())
 
+	case *TransferLeadership:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&TransferLeadershipResult{
+				Success: false,
+				Error:   "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
+	case *ReadIndex:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&ReadIndexResult{
+				Success: false,
+				Error:   "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
+	case *NodeStatus:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&NodeStatusResult{
+				Error: "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
+	case *CommitOffset:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&CommitOffsetResult{
+				Success: false,
+				Error:   "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
+	case *FetchOffset:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&FetchOffsetResult{
+				Success: false,
+				Error:   "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
+	case *Ack:
+		if topic, ok := pod.topics[msg.Topic]; ok {
+			act.Engine().SendWithSender(topic, msg, act.Sender())
+		}
+
+	case *Nack:
+		if topic, ok := pod.topics[msg.Topic]; ok {
+			act.Engine().SendWithSender(topic, msg, act.Sender())
+		}
+
+	case *Redrive:
+		topic, ok := pod.topics[msg.Topic]
+		if !ok {
+			act.Respond(&RedriveResult{
+				Success: false,
+				Error:   "topic does not exist",
+			})
+			return
+		}
+		act.Engine().SendWithSender(topic, msg, act.Sender())
+
 	case *RegisterConsumer:
 		topic, ok := pod.topics[msg.Topic]
 		if !ok {
@@ -61,6 +174,13 @@ func (pod *podActor) Receive(act *actor.Context) {
 			})
 			return
 		}
+		if _, ok := pod.topics[msg.DeadLetterTopic]; msg.DeadLetterTopic != "" && !ok {
+			act.Respond(&RegisterConsumerResult{
+				Success: false,
+				Error:   "dead-letter topic does not exist",
+			})
+			return
+		}
 		act.Engine().SendWithSender(topic, msg, act.Sender())
 	}
 }
@@ -0,0 +1,101 @@
+package cluster
+
+import (
+	"slices"
+	"strings"
+)
+
+// handleDuplicateEnvelope answers an envelope that has already been applied without appending it again.
+// The original index and term are only known for the last envelope applied from the producer.
+func (node *RaftNode) handleDuplicateEnvelope(act NodeContext, msg *Envelope) bool {
+	if msg.ProducerID == "" {
+		return false
+	}
+	producer, ok := node.producers[msg.ProducerID]
+	if !ok || msg.Sequence > producer.Sequence {
+		return false
+	}
+	result := &EnvelopeResult{
+		Success: true,
+	}
+	if msg.Sequence == producer.Sequence {
+		result.Index = producer.Index
+		result.Term = producer.Term
+	}
+	act.Send(act.Sender(), result)
+	node.config.Logger.Info("Dropped duplicate envelope", "pid", act.PID(), "producer", msg.ProducerID, "sequence", msg.Sequence)
+	return true
+}
+
+// isDuplicate reports whether entry has already been applied from its producer, as producers number
+// their envelopes with increasing sequence numbers. Duplicates are not passed to the state machine.
+func (node *RaftNode) isDuplicate(entry *LogEntry) bool {
+	if entry.ProducerID == "" {
+		return false
+	}
+	producer, ok := node.producers[entry.ProducerID]
+	return ok && entry.Sequence <= producer.Sequence
+}
+
+// recordProducer remembers entry as the last entry applied from its producer.
+// The table is only scanned for expired producers when a new one is added to it.
+func (node *RaftNode) recordProducer(index uint64, entry *LogEntry) {
+	if entry.ProducerID == "" {
+		return
+	}
+	if _, ok := node.producers[entry.ProducerID]; !ok {
+		node.expireProducers(entry.Timestamp)
+	}
+	node.producers[entry.ProducerID] = &ProducerState{
+		ProducerID: entry.ProducerID,
+		Sequence:   entry.Sequence,
+		Index:      index,
+		Term:       entry.Term,
+		Timestamp:  entry.Timestamp,
+	}
+}
+
+// expireProducers forgets the producers whose last entry is more than ProducerTTL older than now.
+// Time is measured by the timestamps of the entries, so every replica forgets a producer at the same entry.
+func (node *RaftNode) expireProducers(now int64) {
+	if node.config.ProducerTTL <= 0 {
+		return
+	}
+	for id, producer := range node.producers {
+		if now-producer.Timestamp > int64(node.config.ProducerTTL) {
+			delete(node.producers, id)
+		}
+	}
+}
+
+// producerStates returns the producer table ordered by producer ID for a snapshot,
+// so that every replica drops the same entries after restoring it.
+func (node *RaftNode) producerStates() []*ProducerState {
+	producers := make([]*ProducerState, 0, len(node.producers))
+	for _, producer := range node.producers {
+		producers = append(producers, producer)
+	}
+	slices.SortFunc(producers, func(a, b *ProducerState) int {
+		return strings.Compare(a.ProducerID, b.ProducerID)
+	})
+	return producers
+}
+
+// restoreProducers replaces the producer table with the one stored in snapshot.
+func (node *RaftNode) restoreProducers(snapshot *Snapshot) {
+	node.producers = make(map[string]*ProducerState)
+	for _, producer := range snapshot.Producers {
+		node.producers[producer.ProducerID] = producer
+	}
+	node.duplicates = make(map[uint64]bool)
+}
+
+// forgetDuplicates drops the indexes of duplicate entries up to and including index once they are compacted.
+// Until then they are kept so that reading the log returns the same messages as were applied.
+func (node *RaftNode) forgetDuplicates(index uint64) {
+	for duplicate := range node.duplicates {
+		if duplicate <= index {
+			delete(node.duplicates, duplicate)
+		}
+	}
+}
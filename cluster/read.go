@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// handleReadIndex follows the ReadIndex protocol from the Raft dissertation: the leader records its commit index,
+// confirms it is still the leader with a round of heartbeats sent after the read arrived, and answers once
+// that index has been applied. With ReadLease set, a leader holding a lease skips the heartbeat round.
+// Results carry the ID of their read, as reads confirmed by the lease can be answered before earlier ones.
+func (node *RaftNode) handleReadIndex(act NodeContext, msg *ReadIndex) {
+	node.config.Logger.Info("handleReadIndex", "pid", act.PID(), "sender", act.Sender(), "msg", msg)
+	if !pidEquals(node.leader, act.PID()) {
+		act.Send(act.Sender(), &ReadIndexResult{
+			Success:     false,
+			Error:       "not the leader",
+			RedirectPID: node.leaderRedirect(),
+			Id:          msg.Id,
+		})
+		return
+	}
+
+	read := &readMetadata{
+		id:     msg.Id,
+		sender: act.Sender(),
+	}
+	if node.config.CommandTimeout > 0 {
+		read.deadline = act.Now().Add(node.config.CommandTimeout)
+	}
+	if node.config.ReadLease && node.hasLease(act) {
+		read.confirmed = true
+	} else {
+		node.sendAppendEntriesAll(act, true)
+		read.seq = node.readSeq
+	}
+	node.pendingReads = append(node.pendingReads, read)
+}
+
+// updateReads answers the pending reads whose index has been applied.
+func (node *RaftNode) updateReads(act NodeContext) {
+	if len(node.pendingReads) == 0 {
+		return
+	}
+	if !pidEquals(node.leader, act.PID()) {
+		redirectPID := node.leaderRedirect()
+		for _, read := range node.pendingReads {
+			act.Send(read.sender, &ReadIndexResult{
+				Success:     false,
+				Error:       "not the leader",
+				RedirectPID: redirectPID,
+				Id:          read.id,
+			})
+		}
+		node.pendingReads = nil
+		return
+	}
+
+	// The commit index is only known to be up to date once an entry from the current term has committed
+	termCommitted := false
+	if term, err := node.store.Term(node.commitIndex); err == nil && term == node.currentTerm {
+		termCommitted = true
+	}
+
+	now := act.Now()
+	pendingReads := node.pendingReads[:0]
+	for _, read := range node.pendingReads {
+		if read.index == 0 && termCommitted {
+			read.index = node.commitIndex
+		}
+		if !read.confirmed {
+			read.confirmed = node.readSeqAcknowledged(act, read.seq)
+		}
+		switch {
+		case read.confirmed && read.index > 0 && node.lastApplied >= read.index:
+			act.Send(read.sender, &ReadIndexResult{
+				Success: true,
+				Index:   read.index,
+				Id:      read.id,
+			})
+		case !read.deadline.IsZero() && now.After(read.deadline):
+			act.Send(read.sender, &ReadIndexResult{
+				Success: false,
+				Error:   "read timed out",
+				Id:      read.id,
+			})
+		default:
+			pendingReads = append(pendingReads, read)
+		}
+	}
+	node.pendingReads = pendingReads
+}
+
+// readSeqAcknowledged reports whether a majority of the voters have acknowledged the heartbeat round seq.
+// Followers echo the sequence number of every round, so older heartbeats do not count towards newer reads.
+func (node *RaftNode) readSeqAcknowledged(act NodeContext, seq uint64) bool {
+	acknowledged := 0
+	if node.isVoter(act.PID()) {
+		acknowledged++
+	}
+	for _, metadata := range node.nodes {
+		if !metadata.learner && metadata.readSeq >= seq {
+			acknowledged++
+		}
+	}
+	return acknowledged >= node.quorum()
+}
+
+// hasLease reports whether a majority of the voters have acknowledged a heartbeat round
+// sent less than ElectionMinInterval minus ClockDriftMargin ago. Followers reject pre-votes for
+// ElectionMinInterval after hearing from the leader, which is after the round was sent, so no other
+// leader can be elected before the lease ends as long as clocks run at roughly the same rate.
+// A leadership transfer bypasses the pre-vote, so it also ends the lease.
+func (node *RaftNode) hasLease(act NodeContext) bool {
+	if node.transfer != nil {
+		return false
+	}
+	active := 0
+	if node.isVoter(act.PID()) {
+		active++
+	}
+	lease := node.config.ElectionMinInterval - node.config.ClockDriftMargin
+	for _, metadata := range node.nodes {
+		if !metadata.learner && act.Now().Sub(metadata.leaseStart) < lease {
+			active++
+		}
+	}
+	return active >= node.quorum()
+}
+
+// startHeartbeatRound gives the next heartbeats a new sequence number and records when they were sent.
+// Rounds sent more than ElectionMinInterval ago can no longer extend the lease and are forgotten.
+func (node *RaftNode) startHeartbeatRound(act NodeContext) {
+	now := act.Now()
+	node.heartbeats = slices.DeleteFunc(node.heartbeats, func(round heartbeatRound) bool {
+		return now.Sub(round.sent) >= node.config.ElectionMinInterval
+	})
+	node.readSeq++
+	node.heartbeats = append(node.heartbeats, heartbeatRound{
+		seq:  node.readSeq,
+		sent: now,
+	})
+}
+
+// heartbeatSent returns the time the heartbeat round seq was sent, if it is still remembered.
+func (node *RaftNode) heartbeatSent(seq uint64) (time.Time, bool) {
+	i, ok := slices.BinarySearchFunc(node.heartbeats, seq, func(round heartbeatRound, seq uint64) int {
+		return cmp.Compare(round.seq, seq)
+	})
+	if !ok {
+		return time.Time{}, false
+	}
+	return node.heartbeats[i].sent, true
+}
@@ -0,0 +1,54 @@
+package cluster
+
+// handleReadLog reads applied messages from the node's own log, which any replica can serve,
+// as everything up to its last applied index is committed and never changes.
+// A read that starts before the first entry left after compaction starts at that entry instead,
+// and reports it as FirstAvailable so the reader knows the entries in between are gone.
+func (node *RaftNode) handleReadLog(act NodeContext, msg *ReadLog) {
+	result := &ReadLogResult{
+		Id:          msg.Id,
+		Next:        msg.From,
+		LastApplied: node.lastApplied,
+	}
+	defer func() {
+		act.Send(act.Sender(), result)
+	}()
+
+	snapshot, err := node.store.Snapshot()
+	if err != nil {
+		node.config.Logger.Error("handleReadLog", "pid", act.PID(), "error", err)
+		result.Error = err.Error()
+		return
+	}
+	from := max(msg.From, snapshot.LastIncludedIndex+1)
+	if msg.From < from && snapshot.LastIncludedIndex > 0 {
+		result.FirstAvailable = from
+	}
+	if from > node.lastApplied {
+		result.Next = max(msg.From, node.lastApplied+1)
+		return
+	}
+	to := node.lastApplied + 1
+	if msg.MaxEntries > 0 {
+		to = min(to, from+msg.MaxEntries)
+	}
+	entries, err := node.store.Entries(from, to)
+	if err != nil {
+		node.config.Logger.Error("handleReadLog", "pid", act.PID(), "error", err)
+		result.Error = err.Error()
+		return
+	}
+	for i, entry := range entries {
+		index := from + uint64(i)
+		if entry.Message == nil || node.duplicates[index] {
+			continue
+		}
+		result.Envelopes = append(result.Envelopes, &ConsumerEnvelope{
+			Message:         entry.Message,
+			Offset:          index,
+			Term:            entry.Term,
+			AppendTimestamp: entry.Timestamp,
+		})
+	}
+	result.Next = to
+}
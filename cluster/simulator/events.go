@@ -0,0 +1,58 @@
+package simulator
+
+import (
+	"container/heap"
+	"reflect"
+	"time"
+
+	"github.com/anthdm/hollywood/actor"
+)
+
+// event is a message delivery or timer firing scheduled on the virtual clock.
+// Events at the same time are processed in the order they were scheduled.
+type event struct {
+	at    time.Time
+	seq   uint64
+	node  int
+	epoch uint64
+	from  *actor.PID
+	msg   any
+	// timer and generation identify the timer that scheduled the event, if any
+	timer      reflect.Type
+	generation uint64
+}
+
+type eventQueue []*event
+
+func (queue eventQueue) Len() int {
+	return len(queue)
+}
+
+func (queue eventQueue) Less(i, j int) bool {
+	if queue[i].at.Equal(queue[j].at) {
+		return queue[i].seq < queue[j].seq
+	}
+	return queue[i].at.Before(queue[j].at)
+}
+
+func (queue eventQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *eventQueue) Push(x any) {
+	*queue = append(*queue, x.(*event))
+}
+
+func (queue *eventQueue) Pop() any {
+	old := *queue
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*queue = old[:len(old)-1]
+	return e
+}
+
+func (sim *Simulator) schedule(e *event) {
+	sim.seq++
+	e.seq = sim.seq
+	heap.Push(&sim.queue, e)
+}
@@ -0,0 +1,487 @@
+// Package simulator runs a Raft group of cluster.RaftNodes on a virtual clock and an in-memory network.
+//
+// Every source of nondeterminism is derived from a single seed: election timeouts,
+// message latencies and dropped messages. Running a scenario twice with the same seed
+// processes exactly the same events in the same order, so a failure can be replayed from its seed.
+package simulator
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anthdm/hollywood/actor"
+	"github.com/troygilman/actormq/cluster"
+	"google.golang.org/protobuf/proto"
+)
+
+const address = "simulator"
+
+var (
+	discoveryPID = actor.NewPID(address, "discovery")
+	clientPID    = actor.NewPID(address, "client")
+)
+
+type Config struct {
+	Seed       int64
+	Nodes      int
+	NodeConfig cluster.NodeConfig
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	DropRate   float64
+}
+
+func NewConfig() Config {
+	return Config{
+		Seed:       1,
+		Nodes:      3,
+		NodeConfig: cluster.NewNodeConfig().WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		MinLatency: time.Millisecond,
+		MaxLatency: 10 * time.Millisecond,
+	}
+}
+
+func (config Config) WithSeed(seed int64) Config {
+	config.Seed = seed
+	return config
+}
+
+// ClientMessage is a message sent by a node to the simulated client.
+type ClientMessage struct {
+	At   time.Time
+	Node int
+	Msg  any
+}
+
+// Simulator drives a group of nodes one event at a time.
+type Simulator struct {
+	config    Config
+	rand      *rand.Rand
+	now       time.Time
+	seq       uint64
+	queue     eventQueue
+	nodes     []*simNode
+	partition map[int]int
+	outbox    []*outgoing
+	client    []ClientMessage
+	observers []func(sim *Simulator)
+	checker   *cluster.InvariantChecker
+}
+
+type simNode struct {
+	index        int
+	pid          *actor.PID
+	raft         *cluster.RaftNode
+	store        cluster.LogStore
+	stateMachine *StateMachine
+	rand         *rand.Rand
+	up           bool
+	epoch        uint64
+	timers       map[reflect.Type]uint64
+}
+
+type outgoing struct {
+	from *actor.PID
+	to   *actor.PID
+	msg  any
+}
+
+// New creates and starts a group of config.Nodes nodes.
+func New(config Config) *Simulator {
+	sim := &Simulator{
+		config:    config,
+		rand:      rand.New(rand.NewSource(config.Seed)),
+		now:       time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		partition: make(map[int]int),
+		checker:   cluster.NewInvariantCheckerWithHandler(func(*cluster.InvariantViolation) {}),
+	}
+	for i := 0; i < config.Nodes; i++ {
+		store, _ := cluster.NewMemoryLogStore()()
+		sim.nodes = append(sim.nodes, &simNode{
+			index:        i,
+			pid:          actor.NewPID(address, fmt.Sprintf("node/%d", i)),
+			store:        store,
+			stateMachine: &StateMachine{},
+			rand:         rand.New(rand.NewSource(sim.rand.Int63())),
+		})
+	}
+	for i := range sim.nodes {
+		sim.Restart(i)
+	}
+	return sim
+}
+
+// Seed returns the seed the simulation was created with.
+func (sim *Simulator) Seed() int64 {
+	return sim.config.Seed
+}
+
+// Now returns the virtual time.
+func (sim *Simulator) Now() time.Time {
+	return sim.now
+}
+
+// Nodes returns the number of nodes in the group.
+func (sim *Simulator) Nodes() int {
+	return len(sim.nodes)
+}
+
+// PID returns the PID of node i.
+func (sim *Simulator) PID(i int) *actor.PID {
+	return sim.nodes[i].pid
+}
+
+// Node returns the Raft state of node i.
+func (sim *Simulator) Node(i int) *cluster.RaftNode {
+	return sim.nodes[i].raft
+}
+
+// Store returns the log store of node i, which survives crashes.
+func (sim *Simulator) Store(i int) cluster.LogStore {
+	return sim.nodes[i].store
+}
+
+// StateMachine returns the state machine of node i, which survives crashes.
+func (sim *Simulator) StateMachine(i int) *StateMachine {
+	return sim.nodes[i].stateMachine
+}
+
+// Up reports whether node i is running.
+func (sim *Simulator) Up(i int) bool {
+	return sim.nodes[i].up
+}
+
+// ClientMessages returns every message the nodes have sent to the client.
+func (sim *Simulator) ClientMessages() []ClientMessage {
+	return sim.client
+}
+
+// Err returns the first Raft invariant violated by the nodes, if any.
+// The simulation stops processing events once an invariant is violated.
+func (sim *Simulator) Err() error {
+	return sim.checker.Err()
+}
+
+// Observe calls observer after every event.
+func (sim *Simulator) Observe(observer func(sim *Simulator)) {
+	sim.observers = append(sim.observers, observer)
+}
+
+// Leader returns the running node that believes it leads the highest term, or -1 if there is none.
+func (sim *Simulator) Leader() int {
+	leader := -1
+	var term uint64
+	for i, node := range sim.nodes {
+		if !node.up {
+			continue
+		}
+		status := node.raft.Status()
+		if status.Leader == nil || cluster.PIDToActorPID(status.Leader).String() != node.pid.String() {
+			continue
+		}
+		if leader == -1 || status.Term > term {
+			leader, term = i, status.Term
+		}
+	}
+	return leader
+}
+
+// Propose sends envelope to node i from the client.
+// The EnvelopeResult is recorded in ClientMessages.
+func (sim *Simulator) Propose(i int, envelope *cluster.Envelope) {
+	sim.Send(i, envelope)
+}
+
+// Send delivers msg to node i from the client without delay.
+func (sim *Simulator) Send(i int, msg any) {
+	sim.schedule(&event{
+		at:    sim.now,
+		node:  i,
+		epoch: sim.nodes[i].epoch,
+		from:  clientPID,
+		msg:   msg,
+	})
+}
+
+// Crash stops node i, discarding its timers and the messages in flight to it.
+// Its log store and state machine are kept for Restart.
+func (sim *Simulator) Crash(i int) {
+	node := sim.nodes[i]
+	if !node.up {
+		return
+	}
+	sim.step(node, nil, actor.Stopped{})
+	node.up = false
+	node.epoch++
+}
+
+// Restart starts node i again from its log store and state machine.
+func (sim *Simulator) Restart(i int) {
+	node := sim.nodes[i]
+	if node.up {
+		return
+	}
+	config := sim.config.NodeConfig
+	config.DiscoveryPID = discoveryPID
+	config.Transport = nil
+	config.StateMachine = node.stateMachine
+	config.Observer = sim.checker
+	config.LogStore = func() (cluster.LogStore, error) {
+		return node.store, nil
+	}
+	node.raft = cluster.NewRaftNode(config)
+	node.timers = make(map[reflect.Type]uint64)
+	node.epoch++
+	node.up = true
+	sim.step(node, nil, actor.Started{})
+}
+
+// Partition splits the nodes into groups that can only reach nodes in the same group.
+// Nodes that are not listed form a group of their own.
+func (sim *Simulator) Partition(groups ...[]int) {
+	sim.partition = make(map[int]int)
+	for group, nodes := range groups {
+		for _, i := range nodes {
+			sim.partition[i] = group + 1
+		}
+	}
+}
+
+// Heal removes every partition.
+func (sim *Simulator) Heal() {
+	sim.partition = make(map[int]int)
+}
+
+// SetDropRate sets the probability of a message between nodes being lost.
+func (sim *Simulator) SetDropRate(rate float64) {
+	sim.config.DropRate = rate
+}
+
+// SetLatency sets the range of delays for messages between nodes.
+// Messages with different delays overtake each other.
+func (sim *Simulator) SetLatency(min, max time.Duration) {
+	sim.config.MinLatency = min
+	sim.config.MaxLatency = max
+}
+
+// Step processes the next event and reports whether there was one.
+func (sim *Simulator) Step() bool {
+	return sim.stepUntil(time.Time{})
+}
+
+// stepUntil processes the next event if it is due by deadline and reports whether there was one.
+// Stale events are dropped before looking at the deadline, so time never moves past it.
+// A zero deadline processes the next event whenever it is due.
+func (sim *Simulator) stepUntil(deadline time.Time) bool {
+	for sim.queue.Len() > 0 && sim.Err() == nil {
+		e := sim.queue[0]
+		if sim.stale(e) {
+			heap.Pop(&sim.queue)
+			continue
+		}
+		if !deadline.IsZero() && e.at.After(deadline) {
+			return false
+		}
+		heap.Pop(&sim.queue)
+		sim.now = e.at
+		sim.step(sim.nodes[e.node], e.from, e.msg)
+		return true
+	}
+	return false
+}
+
+// stale reports whether e can no longer be delivered: its node restarted or is down,
+// its timer was reset, or its sender is cut off from the node.
+func (sim *Simulator) stale(e *event) bool {
+	node := sim.nodes[e.node]
+	if !node.up || e.epoch != node.epoch {
+		return true
+	}
+	if e.timer != nil && node.timers[e.timer] != e.generation {
+		return true
+	}
+	return e.timer == nil && e.from != clientPID && !sim.connected(sim.indexOf(e.from), e.node)
+}
+
+// RunFor processes every event in the next d of virtual time.
+func (sim *Simulator) RunFor(d time.Duration) {
+	deadline := sim.now.Add(d)
+	for sim.stepUntil(deadline) {
+	}
+	sim.now = deadline
+}
+
+// RunUntil processes events until condition holds, giving up after d of virtual time.
+func (sim *Simulator) RunUntil(condition func() bool, d time.Duration) bool {
+	deadline := sim.now.Add(d)
+	for !condition() {
+		if !sim.stepUntil(deadline) {
+			sim.now = deadline
+			return false
+		}
+	}
+	return true
+}
+
+// Run runs scenario once for every seed in [from, from+count) and returns the first error
+// or invariant violation, annotated with the seed that produced it.
+func Run(config Config, from int64, count int64, scenario func(sim *Simulator) error) error {
+	for seed := from; seed < from+count; seed++ {
+		sim := New(config.WithSeed(seed))
+		err := scenario(sim)
+		// An invariant violation explains a failed scenario better than its symptoms
+		if violation := sim.Err(); violation != nil {
+			err = violation
+		}
+		if err != nil {
+			return fmt.Errorf("seed %d: %w", seed, err)
+		}
+	}
+	return nil
+}
+
+func (sim *Simulator) step(node *simNode, sender *actor.PID, msg any) {
+	node.raft.Step(&simContext{
+		sim:    sim,
+		node:   node,
+		sender: sender,
+	}, msg)
+	sim.flush()
+	for _, observer := range sim.observers {
+		observer(sim)
+	}
+}
+
+// flush sends the messages produced by a step. They are ordered by destination first,
+// as the node may have produced them by iterating over a map.
+func (sim *Simulator) flush() {
+	outbox := sim.outbox
+	sim.outbox = nil
+	slices.SortStableFunc(outbox, func(a, b *outgoing) int {
+		return strings.Compare(a.to.String(), b.to.String())
+	})
+	for _, out := range outbox {
+		from := sim.indexOf(out.from)
+		switch {
+		case out.to.String() == clientPID.String():
+			sim.client = append(sim.client, ClientMessage{
+				At:   sim.now,
+				Node: from,
+				Msg:  out.msg,
+			})
+		case out.to.String() == discoveryPID.String():
+			sim.handleDiscovery(from, out.msg)
+		default:
+			to := sim.indexOf(out.to)
+			if to == -1 {
+				continue
+			}
+			if !sim.connected(from, to) || sim.rand.Float64() < sim.config.DropRate {
+				continue
+			}
+			latency := sim.config.MinLatency
+			if sim.config.MaxLatency > sim.config.MinLatency {
+				latency += time.Duration(sim.rand.Int63n(int64(sim.config.MaxLatency - sim.config.MinLatency)))
+			}
+			if msg, ok := out.msg.(proto.Message); ok {
+				out.msg = proto.Clone(msg)
+			}
+			sim.schedule(&event{
+				at:    sim.now.Add(latency),
+				node:  to,
+				epoch: sim.nodes[to].epoch,
+				from:  out.from,
+				msg:   out.msg,
+			})
+		}
+	}
+}
+
+// handleDiscovery answers a node registering with discovery with every node in the group.
+func (sim *Simulator) handleDiscovery(from int, msg any) {
+	if _, ok := msg.(*cluster.RegisterNode); !ok || from == -1 {
+		return
+	}
+	nodes := make([]*cluster.PID, len(sim.nodes))
+	for i, node := range sim.nodes {
+		nodes[i] = cluster.ActorPIDToPID(node.pid)
+	}
+	sim.schedule(&event{
+		at:    sim.now,
+		node:  from,
+		epoch: sim.nodes[from].epoch,
+		from:  discoveryPID,
+		msg: &cluster.ActiveNodes{
+			Nodes: nodes,
+		},
+	})
+}
+
+func (sim *Simulator) connected(from int, to int) bool {
+	if from == -1 || to == -1 {
+		return true
+	}
+	return sim.partition[from] == sim.partition[to]
+}
+
+func (sim *Simulator) indexOf(pid *actor.PID) int {
+	for i, node := range sim.nodes {
+		if node.pid.String() == pid.String() {
+			return i
+		}
+	}
+	return -1
+}
+
+// simContext is the NodeContext of a node while it handles a single event.
+type simContext struct {
+	sim    *Simulator
+	node   *simNode
+	sender *actor.PID
+}
+
+func (ctx *simContext) PID() *actor.PID {
+	return ctx.node.pid
+}
+
+func (ctx *simContext) Sender() *actor.PID {
+	return ctx.sender
+}
+
+func (ctx *simContext) Send(pid *actor.PID, msg any) {
+	if pid == nil {
+		return
+	}
+	ctx.sim.outbox = append(ctx.sim.outbox, &outgoing{
+		from: ctx.node.pid,
+		to:   pid,
+		msg:  msg,
+	})
+}
+
+func (ctx *simContext) Now() time.Time {
+	return ctx.sim.now
+}
+
+func (ctx *simContext) Rand() *rand.Rand {
+	return ctx.node.rand
+}
+
+func (ctx *simContext) SetTimer(msg any, d time.Duration) {
+	key := reflect.TypeOf(msg)
+	ctx.node.timers[key]++
+	ctx.sim.schedule(&event{
+		at:         ctx.sim.now.Add(d),
+		node:       ctx.node.index,
+		epoch:      ctx.node.epoch,
+		msg:        msg,
+		timer:      key,
+		generation: ctx.node.timers[key],
+	})
+}
@@ -0,0 +1,316 @@
+package simulator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/troygilman/actormq/cluster"
+	"google.golang.org/protobuf/proto"
+)
+
+// seeds returns the number of seeds every scenario is run with.
+func seeds() int64 {
+	if testing.Short() {
+		return 20
+	}
+	return 200
+}
+
+// propose sends one message to the current leader, if there is one.
+func propose(sim *Simulator, i int) {
+	if leader := sim.Leader(); leader != -1 {
+		sim.Propose(leader, &cluster.Envelope{
+			Message: &cluster.Message{
+				Data: []byte(fmt.Sprintf("message %d", i)),
+			},
+		})
+	}
+}
+
+// converge heals the group, restarts every node and checks that they elect a leader
+// and agree on the commit index, and that at least min proposals succeeded.
+func converge(sim *Simulator, min int) error {
+	sim.Heal()
+	sim.SetDropRate(0)
+	for i := 0; i < sim.Nodes(); i++ {
+		sim.Restart(i)
+	}
+	if !sim.RunUntil(func() bool { return sim.Leader() != -1 }, 10*time.Second) {
+		return fmt.Errorf("no leader elected after healing")
+	}
+	sim.RunFor(2 * time.Second)
+	leader := sim.Leader()
+	if leader == -1 {
+		return fmt.Errorf("leader lost after healing")
+	}
+	commitIndex := sim.Node(leader).Status().CommitIndex
+	for i := 0; i < sim.Nodes(); i++ {
+		if status := sim.Node(i).Status(); status.CommitIndex != commitIndex {
+			return fmt.Errorf("node %d has commit index %d but leader %d has %d", i, status.CommitIndex, leader, commitIndex)
+		}
+	}
+	succeeded := 0
+	for _, msg := range sim.ClientMessages() {
+		if result, ok := msg.Msg.(*cluster.EnvelopeResult); ok && result.Success {
+			succeeded++
+		}
+	}
+	if succeeded < min {
+		return fmt.Errorf("only %d proposals succeeded", succeeded)
+	}
+	return nil
+}
+
+func TestPartitions(t *testing.T) {
+	err := Run(NewConfig(), 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 60; i++ {
+			switch i {
+			case 10:
+				sim.Partition([]int{sim.Leader()})
+			case 20:
+				sim.Partition([]int{0}, []int{1}, []int{2})
+			case 30:
+				sim.Heal()
+			case 40:
+				sim.Partition([]int{0, 1})
+			}
+			propose(sim, i)
+			sim.RunFor(50 * time.Millisecond)
+		}
+		return converge(sim, 10)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDroppedMessages(t *testing.T) {
+	config := NewConfig()
+	config.DropRate = 0.2
+	err := Run(config, 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 60; i++ {
+			propose(sim, i)
+			sim.RunFor(50 * time.Millisecond)
+		}
+		return converge(sim, 20)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestPipelining sends small batches over a lossy network, so pipelined appends
+// are dropped, retried and delivered out of order.
+func TestPipelining(t *testing.T) {
+	config := NewConfig()
+	config.DropRate = 0.2
+	config.NodeConfig.MaxAppendEntries = 2
+	err := Run(config, 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 60; i++ {
+			propose(sim, i)
+			propose(sim, i)
+			sim.RunFor(20 * time.Millisecond)
+		}
+		return converge(sim, 20)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrashRestart(t *testing.T) {
+	err := Run(NewConfig(), 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 60; i++ {
+			switch {
+			case i%15 == 5:
+				if leader := sim.Leader(); leader != -1 {
+					sim.Crash(leader)
+				}
+			case i%15 == 10:
+				sim.Crash(i % sim.Nodes())
+			case i%15 == 0:
+				for j := 0; j < sim.Nodes(); j++ {
+					sim.Restart(j)
+				}
+			}
+			propose(sim, i)
+			sim.RunFor(50 * time.Millisecond)
+		}
+		return converge(sim, 3)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	config := NewConfig()
+	config.NodeConfig.SnapshotThreshold = 8
+	err := Run(config, 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 60; i++ {
+			if i == 10 {
+				sim.Crash(0)
+			}
+			propose(sim, i)
+			sim.RunFor(20 * time.Millisecond)
+		}
+		return converge(sim, 10)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestStalledRetainer checks that a state machine retaining the whole log only holds compaction
+// back for MaxRetainedEntries.
+func TestStalledRetainer(t *testing.T) {
+	config := NewConfig()
+	config.NodeConfig.SnapshotThreshold = 8
+	config.NodeConfig.MaxRetainedEntries = 16
+	err := Run(config, 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < sim.Nodes(); i++ {
+			sim.StateMachine(i).Retain(1)
+		}
+		for i := 0; i < 60; i++ {
+			propose(sim, i)
+			sim.RunFor(20 * time.Millisecond)
+		}
+		if err := converge(sim, 20); err != nil {
+			return err
+		}
+		for i := 0; i < sim.Nodes(); i++ {
+			snapshot, err := sim.Store(i).Snapshot()
+			if err != nil {
+				return err
+			}
+			// The snapshot that is held back can itself be MaxRetainedEntries behind when it is taken
+			lastLogIndex, _ := sim.Store(i).LastIndexAndTerm()
+			if snapshot.LastIncludedIndex == 0 || lastLogIndex-snapshot.LastIncludedIndex > 2*(8+16) {
+				return fmt.Errorf("node %d retains %d entries", i, lastLogIndex-snapshot.LastIncludedIndex)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestFullRestart crashes every node at once and checks that the group elects a leader again
+// from the configuration in its log, without losing committed entries.
+func TestFullRestart(t *testing.T) {
+	err := Run(NewConfig(), 1, seeds(), func(sim *Simulator) error {
+		for i := 0; i < 20; i++ {
+			propose(sim, i)
+			sim.RunFor(50 * time.Millisecond)
+		}
+		leader := sim.Leader()
+		if leader == -1 {
+			return fmt.Errorf("no leader before the restart")
+		}
+		commitIndex := sim.Node(leader).Status().CommitIndex
+		for i := 0; i < sim.Nodes(); i++ {
+			sim.Crash(i)
+		}
+		for i := 0; i < sim.Nodes(); i++ {
+			sim.Restart(i)
+		}
+		if !sim.RunUntil(func() bool { return sim.Leader() != -1 }, 10*time.Second) {
+			return fmt.Errorf("no leader elected after restarting every node")
+		}
+		propose(sim, 20)
+		sim.RunFor(time.Second)
+		if status := sim.Node(sim.Leader()).Status(); status.CommitIndex <= commitIndex {
+			return fmt.Errorf("commit index %d did not pass %d after the restart", status.CommitIndex, commitIndex)
+		}
+		return converge(sim, 10)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTimeNeverDecreases checks that virtual time only moves forward, even when crashes
+// and partitions leave stale events at the head of the queue.
+func TestTimeNeverDecreases(t *testing.T) {
+	err := Run(NewConfig(), 1, seeds(), func(sim *Simulator) error {
+		now := sim.Now()
+		var err error
+		check := func(sim *Simulator) {
+			if sim.Now().Before(now) && err == nil {
+				err = fmt.Errorf("time went back from %v to %v", now, sim.Now())
+			}
+			now = sim.Now()
+		}
+		sim.Observe(check)
+		for i := 0; i < 60; i++ {
+			switch i % 20 {
+			case 5:
+				sim.Crash(i % sim.Nodes())
+			case 10:
+				sim.Partition([]int{i % sim.Nodes()})
+			case 15:
+				sim.Heal()
+				sim.Restart((i - 10) % sim.Nodes())
+			}
+			propose(sim, i)
+			sim.RunFor(time.Duration(i%7) * time.Millisecond)
+			check(sim)
+			sim.RunUntil(func() bool { return false }, time.Duration(i%5)*time.Millisecond)
+			check(sim)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDeterminism runs the same chaotic scenario twice with every seed
+// and checks that the client sees exactly the same messages.
+func TestDeterminism(t *testing.T) {
+	scenario := func(sim *Simulator) {
+		sim.SetDropRate(0.1)
+		for i := 0; i < 40; i++ {
+			switch i {
+			case 10:
+				sim.Partition([]int{0})
+			case 20:
+				sim.Crash(1)
+			case 30:
+				sim.Heal()
+				sim.Restart(1)
+			}
+			propose(sim, i)
+			sim.RunFor(50 * time.Millisecond)
+		}
+	}
+	for seed := int64(1); seed <= seeds(); seed++ {
+		first := New(NewConfig().WithSeed(seed))
+		scenario(first)
+		second := New(NewConfig().WithSeed(seed))
+		scenario(second)
+		if err := first.Err(); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if err := sameClientMessages(first.ClientMessages(), second.ClientMessages()); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+func sameClientMessages(a []ClientMessage, b []ClientMessage) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("client received %d messages and then %d", len(a), len(b))
+	}
+	for i := range a {
+		msgA, okA := a[i].Msg.(proto.Message)
+		msgB, okB := b[i].Msg.(proto.Message)
+		if !a[i].At.Equal(b[i].At) || a[i].Node != b[i].Node || okA != okB || (okA && !proto.Equal(msgA, msgB)) {
+			return fmt.Errorf("client message %d differs: %v from node %d at %v and %v from node %d at %v",
+				i, a[i].Msg, a[i].Node, a[i].At, b[i].Msg, b[i].Node, b[i].At)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/troygilman/actormq/cluster"
+)
+
+// AppliedEntry is an entry applied to a StateMachine.
+type AppliedEntry struct {
+	Index uint64
+	Entry *cluster.LogEntry
+}
+
+// StateMachine records every entry applied by a node.
+// Its snapshot only holds the index of the last applied entry, so restoring a snapshot
+// that is ahead of the recorded entries leaves a gap in them.
+type StateMachine struct {
+	base    uint64
+	applied []AppliedEntry
+	retain  uint64
+}
+
+// Retain makes the state machine keep the log from index onwards, like a reader that stopped reading.
+// Zero, the default, keeps nothing.
+func (sm *StateMachine) Retain(index uint64) {
+	sm.retain = index
+}
+
+func (sm *StateMachine) RetainFrom() uint64 {
+	if sm.retain == 0 {
+		return math.MaxUint64
+	}
+	return sm.retain
+}
+
+// Applied returns the recorded entries in the order they were applied.
+func (sm *StateMachine) Applied() []AppliedEntry {
+	return sm.applied
+}
+
+// LastIndex returns the index of the last applied entry.
+func (sm *StateMachine) LastIndex() uint64 {
+	if len(sm.applied) == 0 {
+		return sm.base
+	}
+	return max(sm.base, sm.applied[len(sm.applied)-1].Index)
+}
+
+func (sm *StateMachine) Apply(index uint64, entry *cluster.LogEntry) error {
+	sm.applied = append(sm.applied, AppliedEntry{
+		Index: index,
+		Entry: entry,
+	})
+	return nil
+}
+
+func (sm *StateMachine) Snapshot() ([]byte, error) {
+	return binary.AppendUvarint(nil, sm.LastIndex()), nil
+}
+
+func (sm *StateMachine) Restore(data []byte) error {
+	var index uint64
+	if data != nil {
+		var n int
+		index, n = binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("invalid state machine snapshot")
+		}
+	}
+	applied := []AppliedEntry{}
+	for _, entry := range sm.applied {
+		if entry.Index <= index {
+			applied = append(applied, entry)
+		}
+	}
+	sm.base = index
+	sm.applied = applied
+	return nil
+}
@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/anthdm/hollywood/actor"
+	"google.golang.org/protobuf/proto"
+)
+
+// StateMachine is the state a node replicates through its log.
+// Every node applies the same committed entries in the same order,
+// so every replica of a StateMachine ends up with the same state.
+type StateMachine interface {
+	// Apply is called once for every committed entry carrying a Message or an OffsetCommit, in log order.
+	// The entry is retried if Apply returns an error.
+	Apply(index uint64, entry *LogEntry) error
+	// Snapshot returns the state after the last applied entry,
+	// which is stored with the snapshot used to compact the log.
+	Snapshot() ([]byte, error)
+	// Restore replaces the state with data returned by Snapshot.
+	// It is called with nil data when the node starts without a snapshot.
+	Restore(data []byte) error
+}
+
+// LogRetainer is implemented by a StateMachine that reads entries from the log after they have been applied.
+// The node does not compact entries at or after RetainFrom, unless NodeConfig.MaxRetainedEntries
+// have been applied since it wanted to.
+type LogRetainer interface {
+	// RetainFrom returns the first index that must stay in the log.
+	RetainFrom() uint64
+}
+
+// consumerStateMachine forwards every committed message to an actor as a ConsumerEnvelope
+// stamped with its position in the log, and keeps the offsets committed by consumer groups.
+// It retains the log from the lowest offset needed by a consumer group or by a subscription of the topic,
+// and tells the topic when a snapshot replaces its state, as the messages the snapshot covers are never applied.
+// The offsets are read by the topic while the node applies entries, so they are guarded by a mutex.
+type consumerStateMachine struct {
+	engine        *actor.Engine
+	pid           *actor.PID
+	mu            sync.Mutex
+	offsets       map[string]uint64
+	subscriptions uint64
+}
+
+// snapshotRestored is sent to the topic when the state machine is restored from a snapshot.
+type snapshotRestored struct{}
+
+// NewConsumerStateMachine returns a StateMachine that sends every committed message
+// to pid as a ConsumerEnvelope.
+func NewConsumerStateMachine(engine *actor.Engine, pid *actor.PID) StateMachine {
+	return newConsumerStateMachine(engine, pid)
+}
+
+func newConsumerStateMachine(engine *actor.Engine, pid *actor.PID) *consumerStateMachine {
+	return &consumerStateMachine{
+		engine:        engine,
+		pid:           pid,
+		offsets:       make(map[string]uint64),
+		subscriptions: math.MaxUint64,
+	}
+}
+
+func (sm *consumerStateMachine) Apply(index uint64, entry *LogEntry) error {
+	if entry.OffsetCommit != nil {
+		sm.mu.Lock()
+		// A commit that arrives late never moves the group back
+		sm.offsets[entry.OffsetCommit.Group] = max(sm.offsets[entry.OffsetCommit.Group], entry.OffsetCommit.Offset)
+		sm.mu.Unlock()
+		return nil
+	}
+	sm.engine.Send(sm.pid, &ConsumerEnvelope{
+		Message:         entry.Message,
+		Offset:          index,
+		Term:            entry.Term,
+		AppendTimestamp: entry.Timestamp,
+	})
+	return nil
+}
+
+// Offset returns the offset last committed by group.
+func (sm *consumerStateMachine) Offset(group string) (uint64, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	offset, ok := sm.offsets[group]
+	return offset, ok
+}
+
+// retainSubscriptions keeps the log from offset onwards for the topic's subscriptions.
+func (sm *consumerStateMachine) retainSubscriptions(offset uint64) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.subscriptions = offset
+}
+
+func (sm *consumerStateMachine) RetainFrom() uint64 {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	retain := sm.subscriptions
+	for _, offset := range sm.offsets {
+		retain = min(retain, offset)
+	}
+	return retain
+}
+
+func (sm *consumerStateMachine) Snapshot() ([]byte, error) {
+	sm.mu.Lock()
+	offsets := &ConsumerOffsets{}
+	for group, offset := range sm.offsets {
+		offsets.Offsets = append(offsets.Offsets, &OffsetCommit{
+			Group:  group,
+			Offset: offset,
+		})
+	}
+	sm.mu.Unlock()
+	slices.SortFunc(offsets.Offsets, func(a, b *OffsetCommit) int {
+		return strings.Compare(a.Group, b.Group)
+	})
+	return proto.MarshalOptions{Deterministic: true}.Marshal(offsets)
+}
+
+func (sm *consumerStateMachine) Restore(data []byte) error {
+	offsets := &ConsumerOffsets{}
+	if err := proto.Unmarshal(data, offsets); err != nil {
+		return err
+	}
+	sm.mu.Lock()
+	sm.offsets = make(map[string]uint64)
+	for _, offset := range offsets.Offsets {
+		sm.offsets[offset.Group] = offset.Offset
+	}
+	sm.mu.Unlock()
+	sm.engine.Send(sm.pid, snapshotRestored{})
+	return nil
+}
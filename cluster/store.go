@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCompacted is returned when reading log entries that have been replaced by a snapshot.
+var ErrCompacted = errors.New("log entries have been compacted")
+
+// LogStore holds the Raft log, the latest snapshot and the hard state of a node.
+// Log indexes start at 1 and an index of 0 refers to the empty log.
+type LogStore interface {
+	// Append adds entries to the end of the log.
+	Append(entries ...*LogEntry) error
+	// TruncateSuffix deletes the entry at index and every entry after it.
+	TruncateSuffix(index uint64) error
+	// Entries returns the entries in the range [lo, hi).
+	Entries(lo, hi uint64) ([]*LogEntry, error)
+	// Term returns the term of the entry at index.
+	Term(index uint64) (uint64, error)
+	// LastIndexAndTerm returns the index and term of the last entry in the log.
+	LastIndexAndTerm() (uint64, uint64)
+	// Snapshot returns the latest snapshot.
+	Snapshot() (*Snapshot, error)
+	// SaveSnapshot stores snapshot and discards every entry it covers.
+	// Entries following the snapshot are kept if the log contains the snapshot's last included entry.
+	SaveSnapshot(snapshot *Snapshot) error
+	HardState() (*HardState, error)
+	SetHardState(state *HardState) error
+	Close() error
+}
+
+// RecoveringLogStore is implemented by a LogStore that can drop corrupted entries from the end of its log when it is opened.
+// The node then waits for a leader to send the entries again before it votes.
+type RecoveringLogStore interface {
+	// RecoveryError returns the error that made the store drop entries, or nil if the log was read intact.
+	RecoveryError() error
+}
+
+// LogStoreProducer opens the LogStore of a node when it starts.
+type LogStoreProducer func() (LogStore, error)
+
+type memoryLogStore struct {
+	snapshot *Snapshot
+	entries  []*LogEntry
+	state    *HardState
+}
+
+// NewMemoryLogStore returns a LogStoreProducer that keeps everything in memory,
+// so the log and hard state are lost when the node stops.
+func NewMemoryLogStore() LogStoreProducer {
+	return func() (LogStore, error) {
+		return newMemoryLogStore(nil, nil, nil), nil
+	}
+}
+
+func newMemoryLogStore(snapshot *Snapshot, entries []*LogEntry, state *HardState) *memoryLogStore {
+	if snapshot == nil {
+		snapshot = &Snapshot{}
+	}
+	if state == nil {
+		state = &HardState{}
+	}
+	return &memoryLogStore{
+		snapshot: snapshot,
+		entries:  entries,
+		state:    state,
+	}
+}
+
+// firstIndex returns the index of the first entry not covered by the snapshot.
+func (store *memoryLogStore) firstIndex() uint64 {
+	return store.snapshot.LastIncludedIndex + 1
+}
+
+func (store *memoryLogStore) Append(entries ...*LogEntry) error {
+	store.entries = append(store.entries, entries...)
+	return nil
+}
+
+func (store *memoryLogStore) TruncateSuffix(index uint64) error {
+	if index < store.firstIndex() {
+		return fmt.Errorf("cannot truncate log at index %d: %w", index, ErrCompacted)
+	}
+	if index-store.firstIndex() < uint64(len(store.entries)) {
+		store.entries = store.entries[:index-store.firstIndex()]
+	}
+	return nil
+}
+
+func (store *memoryLogStore) Entries(lo, hi uint64) ([]*LogEntry, error) {
+	if lo < store.firstIndex() {
+		return nil, fmt.Errorf("entries [%d, %d): %w", lo, hi, ErrCompacted)
+	}
+	lastLogIndex, _ := store.LastIndexAndTerm()
+	if lo > hi || hi > lastLogIndex+1 {
+		return nil, fmt.Errorf("entries [%d, %d) out of range for log ending at %d", lo, hi, lastLogIndex)
+	}
+	return slices.Clone(store.entries[lo-store.firstIndex() : hi-store.firstIndex()]), nil
+}
+
+func (store *memoryLogStore) Term(index uint64) (uint64, error) {
+	if index == store.snapshot.LastIncludedIndex {
+		return store.snapshot.LastIncludedTerm, nil
+	}
+	if index < store.firstIndex() {
+		return 0, fmt.Errorf("term at index %d: %w", index, ErrCompacted)
+	}
+	lastLogIndex, _ := store.LastIndexAndTerm()
+	if index > lastLogIndex {
+		return 0, fmt.Errorf("index %d out of range for log ending at %d", index, lastLogIndex)
+	}
+	return store.entries[index-store.firstIndex()].Term, nil
+}
+
+func (store *memoryLogStore) LastIndexAndTerm() (uint64, uint64) {
+	if len(store.entries) == 0 {
+		return store.snapshot.LastIncludedIndex, store.snapshot.LastIncludedTerm
+	}
+	return store.snapshot.LastIncludedIndex + uint64(len(store.entries)), store.entries[len(store.entries)-1].Term
+}
+
+func (store *memoryLogStore) Snapshot() (*Snapshot, error) {
+	return store.snapshot, nil
+}
+
+func (store *memoryLogStore) SaveSnapshot(snapshot *Snapshot) error {
+	if snapshot.LastIncludedIndex <= store.snapshot.LastIncludedIndex {
+		return nil
+	}
+	if term, err := store.Term(snapshot.LastIncludedIndex); err == nil && term == snapshot.LastIncludedTerm {
+		store.entries = slices.Clone(store.entries[snapshot.LastIncludedIndex+1-store.firstIndex():])
+	} else {
+		store.entries = nil
+	}
+	store.snapshot = snapshot
+	return nil
+}
+
+func (store *memoryLogStore) HardState() (*HardState, error) {
+	return proto.Clone(store.state).(*HardState), nil
+}
+
+func (store *memoryLogStore) SetHardState(state *HardState) error {
+	store.state = proto.Clone(state).(*HardState)
+	return nil
+}
+
+func (store *memoryLogStore) Close() error {
+	return nil
+}
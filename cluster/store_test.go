@@ -0,0 +1,198 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+)
+
+// testEntries returns count checksummed entries of term.
+func testEntries(t *testing.T, term uint64, count int) []*LogEntry {
+	t.Helper()
+	entries := make([]*LogEntry, count)
+	for i := range entries {
+		entries[i] = &LogEntry{
+			Term: term,
+			Message: &Message{
+				Data: []byte{byte(term), byte(i)},
+			},
+		}
+	}
+	if err := setChecksums(entries...); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+// logTerms returns the term of every entry in the log after the snapshot.
+func logTerms(t *testing.T, store LogStore) []uint64 {
+	t.Helper()
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastLogIndex, _ := store.LastIndexAndTerm()
+	entries, err := store.Entries(snapshot.LastIncludedIndex+1, lastLogIndex+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terms := make([]uint64, len(entries))
+	for i, entry := range entries {
+		terms[i] = entry.Term
+	}
+	return terms
+}
+
+func equalTerms(a []uint64, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// storeProducers returns a memory and a file LogStoreProducer, the latter in a temporary directory.
+func storeProducers(t *testing.T) map[string]LogStoreProducer {
+	return map[string]LogStoreProducer{
+		"memory": NewMemoryLogStore(),
+		"file":   NewFileLogStore(t.TempDir()),
+	}
+}
+
+func TestLogStoreAppendAndTruncate(t *testing.T) {
+	for name, producer := range storeProducers(t) {
+		t.Run(name, func(t *testing.T) {
+			store, err := producer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			if lastLogIndex, lastLogTerm := store.LastIndexAndTerm(); lastLogIndex != 0 || lastLogTerm != 0 {
+				t.Fatalf("empty log ends at %d term %d", lastLogIndex, lastLogTerm)
+			}
+			if err := store.Append(testEntries(t, 1, 3)...); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Append(testEntries(t, 2, 2)...); err != nil {
+				t.Fatal(err)
+			}
+			if terms := logTerms(t, store); !equalTerms(terms, []uint64{1, 1, 1, 2, 2}) {
+				t.Fatalf("log has terms %v", terms)
+			}
+
+			if err := store.TruncateSuffix(3); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Append(testEntries(t, 3, 1)...); err != nil {
+				t.Fatal(err)
+			}
+			if terms := logTerms(t, store); !equalTerms(terms, []uint64{1, 1, 3}) {
+				t.Fatalf("log has terms %v after truncating", terms)
+			}
+			if term, err := store.Term(3); err != nil || term != 3 {
+				t.Fatalf("term at 3 is %d, %v", term, err)
+			}
+			if _, err := store.Entries(2, 5); err == nil {
+				t.Fatal("read past the end of the log")
+			}
+		})
+	}
+}
+
+func TestLogStoreSnapshot(t *testing.T) {
+	for name, producer := range storeProducers(t) {
+		t.Run(name, func(t *testing.T) {
+			store, err := producer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			if err := store.Append(testEntries(t, 1, 5)...); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.SaveSnapshot(&Snapshot{LastIncludedIndex: 3, LastIncludedTerm: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if terms := logTerms(t, store); !equalTerms(terms, []uint64{1, 1}) {
+				t.Fatalf("log has terms %v after the snapshot", terms)
+			}
+			if _, err := store.Entries(2, 4); !errors.Is(err, ErrCompacted) {
+				t.Fatalf("reading compacted entries returned %v", err)
+			}
+			if term, err := store.Term(3); err != nil || term != 1 {
+				t.Fatalf("term at the snapshot is %d, %v", term, err)
+			}
+			if err := store.TruncateSuffix(2); !errors.Is(err, ErrCompacted) {
+				t.Fatalf("truncating compacted entries returned %v", err)
+			}
+
+			// A snapshot that does not match the log replaces all of it
+			if err := store.SaveSnapshot(&Snapshot{LastIncludedIndex: 4, LastIncludedTerm: 2}); err != nil {
+				t.Fatal(err)
+			}
+			if lastLogIndex, lastLogTerm := store.LastIndexAndTerm(); lastLogIndex != 4 || lastLogTerm != 2 {
+				t.Fatalf("log ends at %d term %d after a conflicting snapshot", lastLogIndex, lastLogTerm)
+			}
+		})
+	}
+}
+
+func TestFileLogStoreReopen(t *testing.T) {
+	producer := NewFileLogStore(t.TempDir())
+	store, err := producer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(testEntries(t, 1, 4)...); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TruncateSuffix(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(testEntries(t, 2, 3)...); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSnapshot(&Snapshot{LastIncludedIndex: 2, LastIncludedTerm: 1, Data: []byte("state")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(testEntries(t, 3, 1)...); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetHardState(&HardState{Term: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = producer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if terms := logTerms(t, store); !equalTerms(terms, []uint64{2, 2, 2, 3}) {
+		t.Fatalf("reopened log has terms %v", terms)
+	}
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.LastIncludedIndex != 2 || string(snapshot.Data) != "state" {
+		t.Fatalf("reopened snapshot is %v", snapshot)
+	}
+	state, err := store.HardState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Term != 3 {
+		t.Fatalf("reopened hard state is %v", state)
+	}
+	if err := store.(RecoveringLogStore).RecoveryError(); err != nil {
+		t.Fatalf("intact log reported %v", err)
+	}
+}
@@ -2,21 +2,36 @@ package cluster
 
 import (
 	"log/slog"
+	"path/filepath"
+	"time"
 
 	"github.com/anthdm/hollywood/actor"
 )
 
 type TopicConfig struct {
-	Topic     string
-	Discovery *actor.PID
-	Logger    *slog.Logger
+	Topic             string
+	Discovery         *actor.PID
+	Logger            *slog.Logger
+	DataDir           string
+	Learner           bool
+	Transport         *actor.PID
+	HeartbeatInterval time.Duration
 }
 
 type topicActor struct {
-	config      TopicConfig
-	messagesPID *actor.PID
-	consumerPID *actor.PID
-	consumers   map[uint64]*actor.PID
+	config               TopicConfig
+	messagesPID          *actor.PID
+	consumerPID          *actor.PID
+	consumers            map[uint64]*consumerMetadata
+	subscriptions        map[uint64]*subscription
+	groups               map[string]*subscription
+	nextSubscriptionID   uint64
+	pingRepeater         actor.SendRepeater
+	stateMachine         *consumerStateMachine
+	pendingFetches       map[uint64]*fetchMetadata
+	pendingRegistrations map[uint64]*registrationMetadata
+	nextReadID           uint64
+	deadLetterPID        *actor.PID
 }
 
 func NewTopic(config TopicConfig) actor.Producer {
@@ -30,18 +45,38 @@ func NewTopic(config TopicConfig) actor.Producer {
 func (topic *topicActor) Receive(act *actor.Context) {
 	switch msg := act.Message().(type) {
 	case actor.Initialized:
-		topic.consumers = make(map[uint64]*actor.PID)
+		topic.consumers = make(map[uint64]*consumerMetadata)
+		topic.subscriptions = make(map[uint64]*subscription)
+		topic.groups = make(map[string]*subscription)
+		topic.pendingFetches = make(map[uint64]*fetchMetadata)
+		topic.pendingRegistrations = make(map[uint64]*registrationMetadata)
 
 	case actor.Started:
 		config := NewNodeConfig().
 			WithDiscoveryPID(topic.config.Discovery).
 			WithLogger(topic.config.Logger)
 		config.Topic = topic.config.Topic
-		topic.messagesPID = act.SpawnChild(NewNode(config), "node")
+		config.Learner = topic.config.Learner
+		config.Transport = topic.config.Transport
+		if topic.config.HeartbeatInterval > 0 {
+			config.HeartbeatInterval = topic.config.HeartbeatInterval
+		}
+		topic.stateMachine = newConsumerStateMachine(act.Engine(), act.PID())
+		config.StateMachine = topic.stateMachine
+		if topic.config.DataDir != "" {
+			config = config.WithLogStore(NewFileLogStore(filepath.Join(topic.config.DataDir, topic.config.Topic)))
+		}
+		// A fixed ID keeps the node's PID, which its log and configuration refer to, across restarts of the pod
+		topic.messagesPID = act.SpawnChild(NewNode(config), "node", actor.WithID("raft"))
 		// topic.consumerPID = act.SpawnChild(NewRaftNode(NewRaftNodeConfig().
 		// 	WithDiscoveryPID(topic.config.Discovery).
 		// 	WithLogger(topic.config.Logger),
 		// ), "node", actor.WithID("consumer"))
+		topic.deadLetterPID = act.SpawnChild(newDeadLetterActor(act.Parent()), "deadletter")
+		topic.pingRepeater = act.SendRepeat(act.PID(), checkConsumers{}, consumerPingInterval)
+
+	case actor.Stopped:
+		topic.pingRepeater.Stop()
 
 	case *actor.Ping:
 		act.Send(act.Sender(), &actor.Pong{})
@@ -49,25 +84,61 @@ func (topic *topicActor) Receive(act *actor.Context) {
 	case *Envelope:
 		act.Engine().SendWithSender(topic.messagesPID, msg, act.Sender())
 
+	case *TransferLeadership:
+		act.Engine().SendWithSender(topic.messagesPID, msg, act.Sender())
+
+	case *ReadIndex:
+		act.Engine().SendWithSender(topic.messagesPID, msg, act.Sender())
+
+	case *NodeStatus:
+		act.Engine().SendWithSender(topic.messagesPID, msg, act.Sender())
+
+	case *CommitOffset:
+		act.Engine().SendWithSender(topic.messagesPID, msg, act.Sender())
+
+	case *CommitOffsetResult:
+		topic.handleCommitOffsetResult(act, msg)
+
+	case *FetchOffset:
+		topic.handleFetchOffset(act, msg)
+
+	case *ReadIndexResult:
+		topic.handleReadIndexResult(act, msg)
+
+	case *NodeStatusResult:
+		topic.handleNodeStatusResult(act, msg)
+
 	case *ConsumerEnvelope:
-		for _, pid := range topic.consumers {
-			act.Send(pid, msg)
-		}
+		topic.handleConsumerEnvelope(act, msg)
+
+	case *ReadLogResult:
+		topic.handleReadLogResult(act, msg)
 
 	case *RegisterConsumer:
-		pid := PIDToActorPID(msg.PID)
-		key := pid.LookupKey()
-		if _, ok := topic.consumers[key]; ok {
-			act.Respond(&RegisterConsumerResult{
-				Success: false,
-				Error:   "consumer is already registered",
-			})
-			return
+		topic.handleRegisterConsumer(act, msg)
+
+	case *Ack:
+		topic.handleAck(act, msg)
+
+	case *Nack:
+		topic.handleNack(act, msg)
+
+	case snapshotRestored:
+		topic.handleSnapshotRestored(act)
+
+	case *deadLetterResult:
+		topic.handleDeadLetterResult(act, msg)
+
+	case *Redrive:
+		act.SpawnChild(newRedriveActor(act.Parent(), topic.messagesPID, act.Sender(), msg), "redrive")
+
+	case checkConsumers:
+		topic.checkConsumers(act)
+
+	case *actor.Pong:
+		if consumer, ok := topic.consumers[act.Sender().LookupKey()]; ok {
+			consumer.lastPong = time.Now()
 		}
-		topic.consumers[key] = pid
-		act.Respond(&RegisterConsumerResult{
-			Success: true,
-		})
 
 	}
 }
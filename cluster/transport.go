@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"github.com/anthdm/hollywood/actor"
+)
+
+// sendRaft sends a heartbeat or election message to pid through the transport if the node has one.
+// Every topic on a pod runs its own Raft group, so the transport saves a message per topic by sending
+// everything bound for the same pod as one RaftBatch. Entries and snapshots are always sent directly.
+func (node *RaftNode) sendRaft(act NodeContext, pid *actor.PID, msg any) {
+	if node.config.Transport == nil {
+		act.Send(pid, msg)
+		return
+	}
+	envelope := newRaftEnvelope(pid, act.PID(), msg)
+	if envelope == nil {
+		act.Send(pid, msg)
+		return
+	}
+	act.Send(node.config.Transport, envelope)
+}
+
+// newRaftEnvelope wraps msg for delivery to target through the transport,
+// returning nil if msg cannot be batched.
+func newRaftEnvelope(target *actor.PID, sender *actor.PID, msg any) *RaftEnvelope {
+	envelope := &RaftEnvelope{
+		Target: ActorPIDToPID(target),
+		Sender: ActorPIDToPID(sender),
+	}
+	switch msg := msg.(type) {
+	case *AppendEntries:
+		envelope.Payload = &RaftEnvelope_AppendEntries{AppendEntries: msg}
+	case *AppendEntriesResult:
+		envelope.Payload = &RaftEnvelope_AppendEntriesResult{AppendEntriesResult: msg}
+	case *PreVote:
+		envelope.Payload = &RaftEnvelope_PreVote{PreVote: msg}
+	case *PreVoteResult:
+		envelope.Payload = &RaftEnvelope_PreVoteResult{PreVoteResult: msg}
+	case *RequestVote:
+		envelope.Payload = &RaftEnvelope_RequestVote{RequestVote: msg}
+	case *RequestVoteResult:
+		envelope.Payload = &RaftEnvelope_RequestVoteResult{RequestVoteResult: msg}
+	default:
+		return nil
+	}
+	return envelope
+}
+
+// raftEnvelopePayload returns the message wrapped by envelope.
+func raftEnvelopePayload(envelope *RaftEnvelope) any {
+	switch payload := envelope.Payload.(type) {
+	case *RaftEnvelope_AppendEntries:
+		return payload.AppendEntries
+	case *RaftEnvelope_AppendEntriesResult:
+		return payload.AppendEntriesResult
+	case *RaftEnvelope_PreVote:
+		return payload.PreVote
+	case *RaftEnvelope_PreVoteResult:
+		return payload.PreVoteResult
+	case *RaftEnvelope_RequestVote:
+		return payload.RequestVote
+	case *RaftEnvelope_RequestVoteResult:
+		return payload.RequestVoteResult
+	}
+	return nil
+}
+
+// raftBatcher collects the RaftEnvelopes sent by the nodes on a pod, grouped by the pod of their target,
+// and sends them once per heartbeat interval.
+type raftBatcher struct {
+	batches map[string]*raftBatch
+}
+
+type raftBatch struct {
+	pod       *actor.PID
+	envelopes []*RaftEnvelope
+}
+
+func newRaftBatcher() *raftBatcher {
+	return &raftBatcher{
+		batches: make(map[string]*raftBatch),
+	}
+}
+
+// add queues envelope for the pod of its target, sending that pod's batch straight away for election messages
+// so an election round trip is not stretched towards the next election timeout.
+func (batcher *raftBatcher) add(act *actor.Context, envelope *RaftEnvelope) {
+	// Nodes are spawned by a topic, which is spawned by the pod
+	pod := ParentPID(ParentPID(PIDToActorPID(envelope.Target)))
+	batch, ok := batcher.batches[pod.String()]
+	if !ok {
+		batch = &raftBatch{
+			pod: pod,
+		}
+		batcher.batches[pod.String()] = batch
+	}
+	batch.envelopes = append(batch.envelopes, envelope)
+	if isElectionEnvelope(envelope) {
+		batcher.send(act, pod.String())
+	}
+}
+
+// flush sends one RaftBatch to every pod with pending envelopes.
+func (batcher *raftBatcher) flush(act *actor.Context) {
+	for key := range batcher.batches {
+		batcher.send(act, key)
+	}
+}
+
+// send sends the pending envelopes for the pod with key as a single RaftBatch.
+func (batcher *raftBatcher) send(act *actor.Context, key string) {
+	batch := batcher.batches[key]
+	act.Send(batch.pod, &RaftBatch{
+		Envelopes: batch.envelopes,
+	})
+	delete(batcher.batches, key)
+}
+
+// isElectionEnvelope reports whether envelope carries a vote or pre-vote request or result.
+func isElectionEnvelope(envelope *RaftEnvelope) bool {
+	switch envelope.Payload.(type) {
+	case *RaftEnvelope_PreVote, *RaftEnvelope_PreVoteResult, *RaftEnvelope_RequestVote, *RaftEnvelope_RequestVoteResult:
+		return true
+	}
+	return false
+}
+
+// deliverRaftBatch hands every envelope in batch to its target as if it came straight from its sender.
+func deliverRaftBatch(act *actor.Context, batch *RaftBatch) {
+	for _, envelope := range batch.Envelopes {
+		payload := raftEnvelopePayload(envelope)
+		if payload == nil {
+			continue
+		}
+		act.Engine().SendWithSender(PIDToActorPID(envelope.Target), payload, PIDToActorPID(envelope.Sender))
+	}
+}
@@ -1,15 +1,103 @@
 package cluster
 
 import (
+	"time"
+
 	"github.com/anthdm/hollywood/actor"
 )
 
 type nodeMetadata struct {
-	pid        *actor.PID
-	nextIndex  uint64
-	matchIndex uint64
+	pid          *actor.PID
+	nextIndex    uint64
+	matchIndex   uint64
+	inflight     int
+	lastResponse time.Time
+	lastAppend   time.Time
+	learner      bool
+	readSeq      uint64
+	leaseStart   time.Time
+}
+
+// heartbeatRound is the sequence number of a round of heartbeats and the time it was sent.
+type heartbeatRound struct {
+	seq  uint64
+	sent time.Time
 }
 
 type commandMetadata struct {
+	sender       *actor.PID
+	term         uint64
+	deadline     time.Time
+	offsetCommit bool
+}
+
+// batchedCommand is an Envelope or an OffsetCommit waiting to be appended to the log.
+type batchedCommand struct {
+	sender       *actor.PID
+	envelope     *Envelope
+	offsetCommit *OffsetCommit
+}
+
+type leadershipTransfer struct {
+	target   *actor.PID
+	sender   *actor.PID
+	deadline time.Time
+}
+
+type consumerMetadata struct {
+	pid          *actor.PID
+	lastPong     time.Time
+	subscription *subscription
+	maxInFlight  int
+	ackTimeout   time.Duration
+	inflight     int
+}
+
+// subscription is a position in a topic's stream shared by its members, which receive its messages in turn.
+// It replays the log until it reaches the last applied entry and then receives messages as they are applied.
+// A consumer without a group has a subscription of its own, while the first member of a group decides
+// where the group's subscription starts and later members join it where it is.
+type subscription struct {
+	id              uint64
+	group           string
+	members         []*consumerMetadata
+	nextMember      int
+	next            uint64
+	live            bool
+	reading         bool
+	readFailed      bool
+	inflight        map[uint64]*inflightMessage
+	deadLetterTopic string
+	maxDeliveries   uint64
+	committed       uint64
+}
+
+// inflightMessage is a message sent to a member of a subscription that has not been acknowledged.
+// Its consumer is nil once the consumer has been removed, and while it is moved to the dead-letter topic.
+type inflightMessage struct {
+	envelope   *ConsumerEnvelope
+	consumer   *consumerMetadata
+	deadline   time.Time
+	deliveries uint64
+	reason     string
+	deadLetter bool
+}
+
+type fetchMetadata struct {
 	sender *actor.PID
+	group  string
+}
+
+type registrationMetadata struct {
+	sender  *actor.PID
+	request *RegisterConsumer
+}
+
+type readMetadata struct {
+	id        uint64
+	sender    *actor.PID
+	seq       uint64
+	index     uint64
+	confirmed bool
+	deadline  time.Time
 }
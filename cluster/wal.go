@@ -0,0 +1,360 @@
+package cluster
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCorruptRecord is returned when a WAL record cannot be replayed.
+var ErrCorruptRecord = errors.New("corrupt wal record")
+
+const (
+	walFileName       = "log.wal"
+	hardStateFileName = "hardstate"
+	snapshotFileName  = "snapshot"
+	// walHeaderSize is the size of a record's length, the checksum of its length and the checksum of the record.
+	walHeaderSize = 12
+	// maxWALRecordSize bounds the length read from a record header, so a corrupted length is never allocated.
+	maxWALRecordSize = 64 << 20
+)
+
+// fileLogStore is a LogStore backed by a write-ahead log on disk.
+// Entries are also kept in memory so reads never touch the disk.
+type fileLogStore struct {
+	*memoryLogStore
+	wal *wal
+}
+
+// NewFileLogStore returns a LogStoreProducer that persists the log and hard state under dir.
+// Every write is synced to disk before it returns.
+func NewFileLogStore(dir string) LogStoreProducer {
+	return func() (LogStore, error) {
+		wal, memoryLogStore, err := openWAL(dir)
+		if err != nil {
+			return nil, err
+		}
+		return &fileLogStore{
+			memoryLogStore: memoryLogStore,
+			wal:            wal,
+		}, nil
+	}
+}
+
+func (store *fileLogStore) Append(entries ...*LogEntry) error {
+	lastLogIndex, _ := store.LastIndexAndTerm()
+	if err := store.wal.append(lastLogIndex+1, entries); err != nil {
+		return err
+	}
+	return store.memoryLogStore.Append(entries...)
+}
+
+func (store *fileLogStore) TruncateSuffix(index uint64) error {
+	if err := store.wal.truncate(index); err != nil {
+		return err
+	}
+	return store.memoryLogStore.TruncateSuffix(index)
+}
+
+func (store *fileLogStore) SaveSnapshot(snapshot *Snapshot) error {
+	if snapshot.LastIncludedIndex <= store.snapshot.LastIncludedIndex {
+		return nil
+	}
+	if err := store.wal.saveSnapshot(snapshot); err != nil {
+		return err
+	}
+	if err := store.memoryLogStore.SaveSnapshot(snapshot); err != nil {
+		return err
+	}
+	return store.wal.rewrite(store.firstIndex(), store.entries)
+}
+
+func (store *fileLogStore) SetHardState(state *HardState) error {
+	if err := store.wal.saveHardState(state); err != nil {
+		return err
+	}
+	return store.memoryLogStore.SetHardState(state)
+}
+
+// RecoveryError returns the error that made the store drop the end of its log when it was opened.
+func (store *fileLogStore) RecoveryError() error {
+	return store.wal.recoveryError
+}
+
+func (store *fileLogStore) Close() error {
+	return store.wal.close()
+}
+
+// wal is an append-only write-ahead log of LogEntry records with the node's
+// hard state (currentTerm and votedFor) and latest snapshot stored next to it.
+// Every record carries the log index of its entry, and a record at an index
+// discards every entry at or after that index when replayed.
+// A record without an entry only truncates the log.
+// Each record is preceded by its length, a CRC-32C of the length and a CRC-32C of the length and the record,
+// so a corrupted length, index or truncation is detected like a corrupted entry.
+// The log is rewritten without the covered entries whenever a snapshot is saved.
+// Replay stops at the first corrupted record, which is dropped together with every record after it.
+type wal struct {
+	dir           string
+	file          *os.File
+	recoveryError error
+}
+
+func openWAL(dir string) (*wal, *memoryLogStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, err
+	}
+
+	state := &HardState{}
+	if err := readProtoFile(filepath.Join(dir, hardStateFileName), state); err != nil {
+		return nil, nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := readProtoFile(filepath.Join(dir, snapshotFileName), snapshot); err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, offset, err := readWALRecords(file, snapshot.LastIncludedIndex+1)
+	var recoveryError error
+	if errors.Is(err, ErrCorruptRecord) {
+		recoveryError = err
+	} else if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	// Drop a partially written record left behind by a crash, or the corrupted end of the log
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &wal{
+		dir:           dir,
+		file:          file,
+		recoveryError: recoveryError,
+	}, newMemoryLogStore(snapshot, entries, state), nil
+}
+
+// readWALRecords replays the records in file and returns the entries from firstIndex onwards.
+// Records before firstIndex are covered by the snapshot and only remain if a crash
+// interrupted a rewrite.
+// A record cut short by the end of the file was torn by a crash while it was written, and ends the replay quietly.
+// A record that fails its checksums, cannot be decoded or does not continue the log stops the replay,
+// and the entries before it are returned with its offset and an error wrapping ErrCorruptRecord.
+func readWALRecords(file *os.File, firstIndex uint64) ([]*LogEntry, int64, error) {
+	entries := []*LogEntry{}
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		var header [walHeaderSize]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return entries, offset, nil
+			}
+			return nil, 0, err
+		}
+		length := binary.LittleEndian.Uint32(header[0:4])
+		if crc32.Checksum(header[0:4], checksumTable) != binary.LittleEndian.Uint32(header[4:8]) {
+			return entries, offset, fmt.Errorf("%w at offset %d: length checksum mismatch", ErrCorruptRecord, offset)
+		}
+		if length > maxWALRecordSize {
+			return entries, offset, fmt.Errorf("%w at offset %d: length %d exceeds %d", ErrCorruptRecord, offset, length, maxWALRecordSize)
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return entries, offset, nil
+			}
+			return nil, 0, err
+		}
+		if walRecordChecksum(header[0:4], data) != binary.LittleEndian.Uint32(header[8:12]) {
+			return entries, offset, fmt.Errorf("%w at offset %d: record checksum mismatch", ErrCorruptRecord, offset)
+		}
+
+		record := &WALRecord{}
+		if err := proto.Unmarshal(data, record); err != nil {
+			return entries, offset, fmt.Errorf("%w at offset %d: %w", ErrCorruptRecord, offset, err)
+		}
+		if record.Entry != nil {
+			if err := verifyChecksum(record.Index, record.Entry); err != nil {
+				return entries, offset, fmt.Errorf("%w at offset %d: %w", ErrCorruptRecord, offset, err)
+			}
+		}
+		nextIndex := firstIndex + uint64(len(entries))
+		if record.Index == 0 || record.Index > nextIndex {
+			return entries, offset, fmt.Errorf("%w at offset %d has index %d but the log ends at %d", ErrCorruptRecord, offset, record.Index, nextIndex-1)
+		}
+		if record.Index < firstIndex {
+			entries = entries[:0]
+		} else {
+			entries = entries[:record.Index-firstIndex]
+			if record.Entry != nil {
+				entries = append(entries, record.Entry)
+			}
+		}
+		offset += int64(len(header) + len(data))
+	}
+}
+
+// append writes entries starting at index and syncs them to disk.
+// Any entries previously written at or after index are discarded.
+func (w *wal) append(index uint64, entries []*LogEntry) error {
+	records := make([]*WALRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = &WALRecord{
+			Index: index + uint64(i),
+			Entry: entry,
+		}
+	}
+	return w.write(records...)
+}
+
+// truncate discards every entry at or after index and syncs the change to disk.
+func (w *wal) truncate(index uint64) error {
+	return w.write(&WALRecord{
+		Index: index,
+	})
+}
+
+func (w *wal) write(records ...*WALRecord) error {
+	buf, err := encodeWALRecords(records)
+	if err != nil {
+		return err
+	}
+	if _, err := w.file.Write(buf); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// rewrite atomically replaces the log with entries starting at index.
+func (w *wal) rewrite(index uint64, entries []*LogEntry) error {
+	records := make([]*WALRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = &WALRecord{
+			Index: index + uint64(i),
+			Entry: entry,
+		}
+	}
+	buf, err := encodeWALRecords(records)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(w.dir, walFileName)
+	if err := writeFileSync(path, buf); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	w.file.Close()
+	w.file = file
+	return nil
+}
+
+func encodeWALRecords(records []*WALRecord) ([]byte, error) {
+	buf := []byte{}
+	for _, record := range records {
+		data, err := proto.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxWALRecordSize {
+			return nil, fmt.Errorf("wal record at index %d is %d bytes, more than %d", record.Index, len(data), maxWALRecordSize)
+		}
+		length := binary.LittleEndian.AppendUint32(nil, uint32(len(data)))
+		buf = append(buf, length...)
+		buf = binary.LittleEndian.AppendUint32(buf, crc32.Checksum(length, checksumTable))
+		buf = binary.LittleEndian.AppendUint32(buf, walRecordChecksum(length, data))
+		buf = append(buf, data...)
+	}
+	return buf, nil
+}
+
+// walRecordChecksum returns the CRC-32C of a record's encoded length followed by the record.
+func walRecordChecksum(length []byte, data []byte) uint32 {
+	return crc32.Update(crc32.Checksum(length, checksumTable), checksumTable, data)
+}
+
+// saveHardState atomically replaces the stored hard state and syncs it to disk.
+func (w *wal) saveHardState(state *HardState) error {
+	data, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(filepath.Join(w.dir, hardStateFileName), data)
+}
+
+// saveSnapshot atomically replaces the stored snapshot and syncs it to disk.
+func (w *wal) saveSnapshot(snapshot *Snapshot) error {
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(filepath.Join(w.dir, snapshotFileName), data)
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
+
+// readProtoFile unmarshals the file at path into msg, leaving msg empty if the file does not exist.
+func readProtoFile(path string, msg proto.Message) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// writeFileSync writes data to a temporary file and renames it over path,
+// syncing both the file and its directory so the replacement is durable.
+func writeFileSync(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
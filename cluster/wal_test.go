@@ -0,0 +1,224 @@
+package cluster
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestWAL writes entries of the given terms to a file log store in a new directory and returns the directory.
+func writeTestWAL(t *testing.T, terms ...uint64) string {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := NewFileLogStore(dir)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, term := range terms {
+		if err := store.Append(testEntries(t, term, 1)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// reopenTestWAL opens the file log store in dir again.
+func reopenTestWAL(t *testing.T, dir string) LogStore {
+	t.Helper()
+	store, err := NewFileLogStore(dir)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		store.Close()
+	})
+	return store
+}
+
+func TestWALTornWrite(t *testing.T) {
+	dir := writeTestWAL(t, 1, 1, 2)
+	path := filepath.Join(dir, walFileName)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	store := reopenTestWAL(t, dir)
+	if terms := logTerms(t, store); !equalTerms(terms, []uint64{1, 1}) {
+		t.Fatalf("log has terms %v after a torn write", terms)
+	}
+	if err := store.(RecoveringLogStore).RecoveryError(); err != nil {
+		t.Fatalf("torn write reported %v", err)
+	}
+
+	// The torn record is cut off, so new entries follow the intact ones
+	if err := store.Append(testEntries(t, 3, 1)...); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+	if terms := logTerms(t, reopenTestWAL(t, dir)); !equalTerms(terms, []uint64{1, 1, 3}) {
+		t.Fatalf("log has terms %v after appending to a torn log", terms)
+	}
+}
+
+func TestWALCrashBeforeRewrite(t *testing.T) {
+	dir := writeTestWAL(t, 1, 1, 2, 2)
+
+	// The snapshot was saved but the log was not rewritten without the entries it covers
+	store := reopenTestWAL(t, dir).(*fileLogStore)
+	if err := store.wal.saveSnapshot(&Snapshot{LastIncludedIndex: 3, LastIncludedTerm: 2}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	reopened := reopenTestWAL(t, dir)
+	if lastLogIndex, lastLogTerm := reopened.LastIndexAndTerm(); lastLogIndex != 4 || lastLogTerm != 2 {
+		t.Fatalf("log ends at %d term %d", lastLogIndex, lastLogTerm)
+	}
+	if terms := logTerms(t, reopened); !equalTerms(terms, []uint64{2}) {
+		t.Fatalf("log has terms %v after the snapshot", terms)
+	}
+}
+
+// walRecordOffsets returns the offset of every record in the WAL in dir.
+func walRecordOffsets(t *testing.T, dir string) []int {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, walFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offsets := []int{}
+	for offset := 0; offset < len(data); offset += walHeaderSize + int(binary.LittleEndian.Uint32(data[offset:])) {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// corruptWAL replaces the WAL in dir with the result of corrupt.
+func corruptWAL(t *testing.T, dir string, corrupt func(data []byte)) {
+	t.Helper()
+	path := filepath.Join(dir, walFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt(data)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWALCorruption(t *testing.T) {
+	tests := []struct {
+		name string
+		// corrupt damages the WAL given the offsets of its five records, the fourth of which truncates the log
+		corrupt func(data []byte, offsets []int)
+		// record is the first corrupted record, and lastIndex the end of the log left before it
+		record    int
+		lastIndex uint64
+	}{
+		{
+			name:      "length",
+			record:    1,
+			lastIndex: 1,
+			corrupt: func(data []byte, offsets []int) {
+				data[offsets[1]+1] ^= 0x01
+			},
+		},
+		{
+			name:      "oversized length",
+			record:    1,
+			lastIndex: 1,
+			corrupt: func(data []byte, offsets []int) {
+				length := binary.LittleEndian.AppendUint32(nil, 0xffffffff)
+				copy(data[offsets[1]:], length)
+				binary.LittleEndian.PutUint32(data[offsets[1]+4:], crc32.Checksum(length, checksumTable))
+			},
+		},
+		{
+			name:      "index",
+			record:    1,
+			lastIndex: 1,
+			corrupt: func(data []byte, offsets []int) {
+				// The index is the first field of the record, after its tag
+				data[offsets[1]+walHeaderSize+1] ^= 0x04
+			},
+		},
+		{
+			name:      "entry",
+			record:    1,
+			lastIndex: 1,
+			corrupt: func(data []byte, offsets []int) {
+				data[offsets[2]-1] ^= 0xff
+			},
+		},
+		{
+			name:      "truncation",
+			record:    3,
+			lastIndex: 3,
+			corrupt: func(data []byte, offsets []int) {
+				data[offsets[3]+walHeaderSize+1] ^= 0x01
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeTestWAL(t, 1, 1, 1)
+			store := reopenTestWAL(t, dir)
+			if err := store.TruncateSuffix(3); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Append(testEntries(t, 2, 1)...); err != nil {
+				t.Fatal(err)
+			}
+			store.Close()
+			offsets := walRecordOffsets(t, dir)
+			if len(offsets) != 5 {
+				t.Fatalf("wal has %d records", len(offsets))
+			}
+			corruptWAL(t, dir, func(data []byte) {
+				test.corrupt(data, offsets)
+			})
+
+			store = reopenTestWAL(t, dir)
+			if err := store.(RecoveringLogStore).RecoveryError(); !errors.Is(err, ErrCorruptRecord) {
+				t.Fatalf("corrupted wal reported %v", err)
+			}
+			if lastLogIndex, _ := store.LastIndexAndTerm(); lastLogIndex != test.lastIndex {
+				t.Fatalf("log ends at %d instead of %d", lastLogIndex, test.lastIndex)
+			}
+			info, err := os.Stat(filepath.Join(dir, walFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(offsets[test.record]) {
+				t.Fatalf("wal is %d bytes after dropping the corrupted records", info.Size())
+			}
+		})
+	}
+}
+
+func TestWALCorruptLastRecord(t *testing.T) {
+	dir := writeTestWAL(t, 1, 1, 2)
+	corruptWAL(t, dir, func(data []byte) {
+		data[len(data)-1] ^= 0xff
+	})
+
+	// A complete record that fails its checksum is corrupted rather than torn, even at the end of the file
+	store := reopenTestWAL(t, dir)
+	if err := store.(RecoveringLogStore).RecoveryError(); !errors.Is(err, ErrCorruptRecord) {
+		t.Fatalf("corrupted last record reported %v", err)
+	}
+	if terms := logTerms(t, store); !equalTerms(terms, []uint64{1, 1}) {
+		t.Fatalf("log has terms %v", terms)
+	}
+}
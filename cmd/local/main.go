@@ -26,10 +26,10 @@ func main() {
 		// Logger: slog.Default(),
 	}
 
-	pods := []*actor.PID{
-		engine.Spawn(cluster.NewPod(config), "pod"),
-		engine.Spawn(cluster.NewPod(config), "pod"),
-		engine.Spawn(cluster.NewPod(config), "pod"),
+	pods := []*actor.PID{}
+	for _, id := range []string{"1", "2", "3"} {
+		config.ID = id
+		pods = append(pods, engine.Spawn(cluster.NewPod(config), "pod", actor.WithID(id)))
 	}
 
 	clientPID := engine.Spawn(client.NewClient(client.ClientConfig{